@@ -1,13 +1,15 @@
 module flamingo.me/flamalyzer
 
-go 1.16
+go 1.23.0
 
 require (
 	flamingo.me/dingo v0.2.9
 	github.com/mitchellh/mapstructure v1.4.1
-	github.com/spf13/pflag v1.0.5
-	golang.org/x/mod v0.4.2 // indirect
-	golang.org/x/sys v0.0.0-20210420205809-ac73e9fd8988 // indirect
-	golang.org/x/tools v0.1.0
+	golang.org/x/tools v0.34.0
 	gopkg.in/yaml.v2 v2.4.0
 )
+
+require (
+	golang.org/x/mod v0.25.0 // indirect
+	golang.org/x/sync v0.15.0 // indirect
+)
@@ -1,6 +1,7 @@
 package analyzers
 
 import (
+	"io"
 	"reflect"
 
 	"flamingo.me/flamalyzer/src/flamalyzer/configuration"
@@ -16,6 +17,18 @@ type Analyzer interface {
 	ChecksToExecute() []*analysis.Analyzer
 }
 
+// GraphExporterProvider returns all bound instances
+type GraphExporterProvider func() []GraphExporter
+
+// GraphExporter exports a static model of the analysed packages, e.g. to review the wiring of an application.
+// It is run by the subcommand `graph <name>` of Flamalyzer.
+type GraphExporter interface {
+	// Name is the name of the graph in the subcommand, e.g. "dingo"
+	Name() string
+	// Export writes the graph of the packages matching the patterns to the writer in the given format, e.g. "dot"
+	Export(w io.Writer, format string, patterns []string) error
+}
+
 // DecodeAnalyzerConfigurationsToAnalyzerProps decodes the props loaded from the config-files to the specific props of an analyzer
 // The props musst be passed a Pointer e.g &props
 func DecodeAnalyzerConfigurationsToAnalyzerProps(entryName string, config configuration.AnalyzerConfig, propsPtr interface{}) {
@@ -5,6 +5,7 @@ import (
 	"flamingo.me/dingo"
 	"flamingo.me/flamalyzer/src/analyzers"
 	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/bind"
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/config"
 	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/inject"
 	"flamingo.me/flamalyzer/src/flamalyzer/configuration"
 	"golang.org/x/tools/go/analysis"
@@ -13,11 +14,31 @@ import (
 // Module to register the dingo checks
 type Module struct{}
 
-// The default properties which are used if there is no config-file
+// The default properties which are used if there is no config-file.
+// The checks enforcing conventions of a code base rather than errors of dingo are opt-in: CheckInjectSignature,
+// CheckUnusedInjection, CheckUnboundInterface, CheckModuleDependencies, CheckConfigKeys and CheckServiceLocator.
 var defaultProps = Props{
 	CheckPointerReceiver:                   true,
 	CheckStrictTagsAndFunctions:            true,
+	CheckInjectSignature:                   false,
+	AllowInjectLogic:                       false,
+	CheckUnusedInjection:                   false,
 	CheckCorrectInterfaceToInstanceBinding: true,
+	CheckCorrectProviderBinding:            true,
+	CheckCorrectInterceptorBinding:         true,
+	CheckDuplicateBinding:                  true,
+	CheckOverrideBinding:                   true,
+	CheckUnboundInterface:                  false,
+	UnboundInterfaceAllowlist:              []string{},
+	CheckDependencyCycle:                   true,
+	CheckModuleDependencies:                false,
+	CheckBindMapKeys:                       true,
+	CheckMultiBindingConsumers:             true,
+	CheckScopeMisuse:                       true,
+	RequestScopedTypes:                     []string{"flamingo.me/flamingo/v3/framework/web.Request", "flamingo.me/flamingo/v3/framework/web.Session", "net/http.Request"},
+	CheckConfigKeys:                        false,
+	CheckServiceLocator:                    false,
+	ServiceLocatorAllowedPaths:             []string{},
 }
 
 // Props of an analyzer which will be used by the config-module to match the entries
@@ -26,7 +47,25 @@ type Props struct {
 	Name                                   string
 	CheckPointerReceiver                   bool
 	CheckStrictTagsAndFunctions            bool
+	CheckInjectSignature                   bool
+	AllowInjectLogic                       bool
+	CheckUnusedInjection                   bool
 	CheckCorrectInterfaceToInstanceBinding bool
+	CheckCorrectProviderBinding            bool
+	CheckCorrectInterceptorBinding         bool
+	CheckDuplicateBinding                  bool
+	CheckOverrideBinding                   bool
+	CheckUnboundInterface                  bool
+	UnboundInterfaceAllowlist              []string
+	CheckDependencyCycle                   bool
+	CheckModuleDependencies                bool
+	CheckBindMapKeys                       bool
+	CheckMultiBindingConsumers             bool
+	CheckScopeMisuse                       bool
+	RequestScopedTypes                     []string
+	CheckConfigKeys                        bool
+	CheckServiceLocator                    bool
+	ServiceLocatorAllowedPaths             []string
 }
 
 // The Analyzer holds a set of checks, uses the config and has props that can be defined to get read by the config
@@ -39,6 +78,7 @@ type Analyzer struct {
 // Configure DI
 func (m *Module) Configure(injector *dingo.Injector) {
 	injector.BindMulti(new(analyzers.Analyzer)).To(new(Analyzer))
+	injector.BindMulti(new(analyzers.GraphExporter)).To(new(GraphExporter))
 }
 
 // Inject dependencies
@@ -51,6 +91,10 @@ func (d *Analyzer) Inject(config configuration.AnalyzerConfig) {
 // ChecksToExecute decides which checks to run
 func (d *Analyzer) ChecksToExecute() []*analysis.Analyzer {
 	analyzers.DecodeAnalyzerConfigurationsToAnalyzerProps(d.props.Name, d.config, &d.props)
+	// The collection of the bindings, which all binding checks use, logs the calls it can't resolve in debug mode
+	if d.config.IsDebug() {
+		_ = bind.BindingsAnalyzer.Flags.Set("debug", "true")
+	}
 
 	if d.props.CheckPointerReceiver {
 		d.checks = append(d.checks, inject.ReceiverAnalyzer)
@@ -58,8 +102,50 @@ func (d *Analyzer) ChecksToExecute() []*analysis.Analyzer {
 	if d.props.CheckStrictTagsAndFunctions {
 		d.checks = append(d.checks, inject.TagAnalyzer)
 	}
+	if d.props.CheckInjectSignature {
+		d.checks = append(d.checks, inject.NewSignatureAnalyzer(d.props.AllowInjectLogic).Analyzer)
+	}
+	if d.props.CheckUnusedInjection {
+		d.checks = append(d.checks, inject.UnusedAnalyzer)
+	}
 	if d.props.CheckCorrectInterfaceToInstanceBinding {
-		d.checks = append(d.checks, bind.Analyzer)
+		d.checks = append(d.checks, bind.NewAnalyzer(d.config.IsDebug()).Analyzer)
+	}
+	if d.props.CheckCorrectProviderBinding {
+		d.checks = append(d.checks, bind.ProviderAnalyzer)
+	}
+	if d.props.CheckCorrectInterceptorBinding {
+		d.checks = append(d.checks, bind.InterceptorAnalyzer)
+	}
+	if d.props.CheckDuplicateBinding {
+		d.checks = append(d.checks, bind.DuplicateAnalyzer)
+	}
+	if d.props.CheckOverrideBinding {
+		d.checks = append(d.checks, bind.OverrideAnalyzer)
+	}
+	if d.props.CheckUnboundInterface {
+		d.checks = append(d.checks, bind.NewUnboundAnalyzer(d.props.UnboundInterfaceAllowlist).Analyzer)
+	}
+	if d.props.CheckDependencyCycle {
+		d.checks = append(d.checks, bind.CycleAnalyzer)
+	}
+	if d.props.CheckModuleDependencies {
+		d.checks = append(d.checks, bind.DependsAnalyzer)
+	}
+	if d.props.CheckBindMapKeys {
+		d.checks = append(d.checks, bind.MapKeyAnalyzer)
+	}
+	if d.props.CheckMultiBindingConsumers {
+		d.checks = append(d.checks, bind.MultiBindingAnalyzer)
+	}
+	if d.props.CheckScopeMisuse {
+		d.checks = append(d.checks, bind.NewScopeAnalyzer(d.props.RequestScopedTypes).Analyzer)
+	}
+	if d.props.CheckConfigKeys {
+		d.checks = append(d.checks, config.NewKeyAnalyzer(d.config.IsDebug()).Analyzer)
+	}
+	if d.props.CheckServiceLocator {
+		d.checks = append(d.checks, bind.NewServiceLocatorAnalyzer(d.props.ServiceLocatorAllowedPaths).Analyzer)
 	}
 	return d.checks
 }
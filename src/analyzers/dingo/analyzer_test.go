@@ -1,10 +1,22 @@
 package dingo_test
 
 import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"flamingo.me/dingo"
+	dingoAnalyzer "flamingo.me/flamalyzer/src/analyzers/dingo"
 	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/bind"
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/config"
 	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/inject"
+	"flamingo.me/flamalyzer/src/analyzers/dingo/graph"
+	"flamingo.me/flamalyzer/src/flamalyzer/flamalyzertest"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -15,10 +27,220 @@ func TestProperInjectTags(t *testing.T) {
 
 func TestBoundToReference(t *testing.T) {
 	analysis := inject.ReceiverAnalyzer
-	analysistest.Run(t, analysistest.TestData(), analysis, "pointer_receiver")
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analysis, "pointer_receiver")
+}
+
+func TestInjectSignature(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), inject.NewSignatureAnalyzer(false).Analyzer, "inject_signature")
+	analysistest.Run(t, analysistest.TestData(), inject.NewSignatureAnalyzer(true).Analyzer, "inject_signature_logic")
+}
+
+func TestUnusedInjection(t *testing.T) {
+	analysis := inject.UnusedAnalyzer
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analysis, "unused_injection")
 }
 
 func TestCorrectInterfaceToInstanceBinding(t *testing.T) {
-	analysis := bind.Analyzer
+	analysis := bind.NewAnalyzer(false).Analyzer
 	analysistest.Run(t, analysistest.TestData(), analysis, "correct_interface_to_instance_binding")
 }
+
+func TestValueBinding(t *testing.T) {
+	analysis := bind.NewAnalyzer(false).Analyzer
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analysis, "value_binding")
+}
+
+func TestCorrectProviderBinding(t *testing.T) {
+	analysis := bind.ProviderAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "correct_provider_binding")
+}
+
+func TestCorrectInterceptorBinding(t *testing.T) {
+	analysis := bind.InterceptorAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "correct_interceptor_binding")
+}
+
+func TestDuplicateBinding(t *testing.T) {
+	analysis := bind.DuplicateAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "duplicate_binding/...")
+}
+
+func TestOverrideBinding(t *testing.T) {
+	analysis := bind.OverrideAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "override_binding/...")
+}
+
+func TestUnboundInterface(t *testing.T) {
+	analysis := bind.NewUnboundAnalyzer([]string{"unbound_interface/external", "unbound_interface/domain.Allowed"}).Analyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "unbound_interface/...")
+}
+
+func TestDependencyCycle(t *testing.T) {
+	analysis := bind.CycleAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "dependency_cycle/...")
+}
+
+func TestModuleDependencies(t *testing.T) {
+	analysis := bind.DependsAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "module_dependencies/...")
+}
+
+func TestBindMapKeys(t *testing.T) {
+	analysis := bind.MapKeyAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "bind_map_keys/...")
+}
+
+func TestMultiBindingConsumers(t *testing.T) {
+	analysis := bind.MultiBindingAnalyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "multi_binding/...")
+}
+
+func TestScopeMisuse(t *testing.T) {
+	analysis := bind.NewScopeAnalyzer([]string{"scope_misuse/web.Request"}).Analyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "scope_misuse/...")
+}
+
+func TestServiceLocator(t *testing.T) {
+	analysis := bind.NewServiceLocatorAnalyzer([]string{"*/bootstrap"}).Analyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "service_locator/...")
+}
+
+// The legacy application doesn't report unknown keys, as the config of its module can't be read, this is logged in debug mode
+func TestConfigKeys(t *testing.T) {
+	var trace bytes.Buffer
+	log.SetOutput(&trace)
+	defer log.SetOutput(os.Stderr)
+
+	analysis := config.NewKeyAnalyzer(true).Analyzer
+	analysistest.Run(t, analysistest.TestData(), analysis, "config_keys/...")
+	want := "config_keys/legacy: unknown config keys are not reported, the config declared by (*legacymodule.Module).DefaultConfig can't be read"
+	if !strings.Contains(trace.String(), want) {
+		t.Errorf("the unreadable config is not logged\n--- want\n%s\n--- got\n%s", want, trace.String())
+	}
+}
+
+func TestBindingModel(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), bind.BindingsAnalyzer, "binding_model")
+	want := []string{
+		"Bind *binding_model.I annotation=\"\" To new(A) scope=\"\" eager=false",
+		"Bind *binding_model.I annotation=\"x\" ToInstance new(A) scope=\"Singleton\" eager=false",
+		"BindMulti *binding_model.I annotation=\"constant\" ToProvider provideA scope=\"Singleton\" eager=true",
+		"BindMap *binding_model.I annotation=\"\" - - scope=\"ChildSingleton\" eager=false",
+		"Override *binding_model.I annotation=\"\" To new(A) scope=\"\" eager=false",
+		"Bind *binding_model.A annotation=\"\" ToProvider provideA scope=\"Singleton\" eager=false",
+	}
+	var got []string
+	for _, binding := range results[0].Result.([]*bind.Binding) {
+		targetKind, target := "-", "-"
+		if binding.TargetCall != nil {
+			targetKind, target = binding.TargetKind, types.ExprString(binding.Target)
+		}
+		got = append(got, fmt.Sprintf("%s %s annotation=%q %s %s scope=%q eager=%t", binding.Kind, binding.Type, binding.Annotation, targetKind, target, binding.Scope, binding.Eager))
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected bindings\n--- want\n%s\n--- got\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestBindingHelpers(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), bind.BindingsAnalyzer, "binding_helpers")
+	want := []string{
+		"(*binding_helpers.Module).Configure: Bind *binding_helpers.I annotation=\"\"",
+		"(*binding_helpers.Module).Configure: Bind *binding_helpers.A annotation=\"helper\"",
+		"(*binding_helpers.Module).Configure: BindMap *binding_helpers.I annotation=\"\"",
+		"(*binding_helpers.Module).Configure: Override *binding_helpers.I annotation=\"\"",
+		"(*binding_helpers.Module).Configure: BindMulti *binding_helpers.I annotation=\"\"",
+		"binding_helpers.Standalone: Bind *binding_helpers.A annotation=\"standalone\"",
+		"<nil>: Bind *binding_helpers.A annotation=\"literal\"",
+		"binding_helpers.main: Bind *binding_helpers.A annotation=\"main\"",
+	}
+	var got []string
+	for _, binding := range results[0].Result.([]*bind.Binding) {
+		function := "<nil>"
+		if binding.Function != nil {
+			function = binding.Function.FullName()
+		}
+		got = append(got, fmt.Sprintf("%s: %s %s annotation=%q", function, binding.Kind, binding.Type, binding.Annotation))
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected bindings\n--- want\n%s\n--- got\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestGraph(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), bind.GraphAnalyzer, "graph/cmd")
+	dingoGraph := results[0].Result.(*bind.Graph)
+	dingoGraph.RelativeTo(filepath.Join(analysistest.TestData(), "src"))
+	for _, format := range graph.Formats {
+		var out bytes.Buffer
+		if err := graph.Write(&out, dingoGraph, format); err != nil {
+			t.Fatal(err)
+		}
+		flamalyzertest.Golden(t, filepath.Join(analysistest.TestData(), "graph."+format+".golden"), out.Bytes())
+	}
+}
+
+// The export loads the packages itself, the same way Flamalyzer does when it is run by `graph dingo`.
+// The packages of the graph testdata are merged into the graph of the main package, which imports all of them.
+func TestGraphExport(t *testing.T) {
+	for _, format := range graph.Formats {
+		flamalyzertest.Golden(t, filepath.Join(analysistest.TestData(), "graph."+format+".golden"), exportGraph(t, format, "graph/..."))
+	}
+}
+
+// The bindings of a generic helper function share the location, they are kept once per instance when the graphs are merged
+func TestGraphExportGenerics(t *testing.T) {
+	flamalyzertest.Golden(t, filepath.Join(analysistest.TestData(), "graph_generics.json.golden"), exportGraph(t, "json", "generics/..."))
+}
+
+// Exports the dingo graph of the testdata packages matching the patterns with locations relative to the `src` folder
+func exportGraph(t *testing.T, format string, patterns ...string) []byte {
+	dir, err := filepath.Abs(analysistest.TestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The testdata follows the GOPATH layout like analysistest expects it
+	t.Setenv("GOPATH", dir)
+	t.Setenv("GO111MODULE", "off")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOPROXY", "off")
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(filepath.Join(dir, "src")); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	var out bytes.Buffer
+	if err := new(dingoAnalyzer.GraphExporter).Export(&out, format, patterns); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestConfiguredChecks(t *testing.T) {
+	flamalyzertest.Run(t, analysistest.TestData(), []dingo.Module{new(dingoAnalyzer.Module)}, "configured_checks")
+}
+
+// A binding function called through a function value can't be resolved, the call is logged in debug mode
+func TestOddBindings(t *testing.T) {
+	var trace bytes.Buffer
+	log.SetOutput(&trace)
+	defer log.SetOutput(os.Stderr)
+	if err := bind.BindingsAnalyzer.Flags.Set("debug", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = bind.BindingsAnalyzer.Flags.Set("debug", "false") }()
+
+	flamalyzertest.Run(t, analysistest.TestData(), []dingo.Module{new(dingoAnalyzer.Module)}, "odd_bindings")
+	want := "test_odd_bindings.go:83:2: skipped the call of bind, a binding function called through a function value can't be resolved"
+	if !strings.Contains(trace.String(), want) {
+		t.Errorf("the unresolved call is not logged\n--- want\n%s\n--- got\n%s", want, trace.String())
+	}
+}
+
+func TestGenerics(t *testing.T) {
+	flamalyzertest.Run(t, analysistest.TestData(), []dingo.Module{new(dingoAnalyzer.Module)}, "generics/store", "generics/app")
+}
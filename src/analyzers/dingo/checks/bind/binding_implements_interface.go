@@ -6,116 +6,176 @@ import (
 	"go/types"
 
 	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"flamingo.me/flamalyzer/src/flamalyzer/log"
 	"golang.org/x/tools/go/analysis"
-	"golang.org/x/tools/go/analysis/passes/inspect"
-	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
-// Analyzer checks if a dingo binding to an interface really implements the interface
-var Analyzer = &analysis.Analyzer{
-	Name:     "checkCorrectInterfaceToInstanceBinding",
-	Doc:      "check if the Binding of an Interface to an Implementation with the Bind() -Function is possible",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
-}
+// Analyzer checks if a dingo binding to an interface really implements the interface, without logging skipped bindings.
+//
+// Deprecated: Use NewAnalyzer, which logs the skipped bindings in debug mode.
+var Analyzer = NewAnalyzer(false).Analyzer
 
-var dingoTypeDecl = "dingo.Injector"
-var dingoPkgPath = "flamingo.me/dingo"
+type correctBindingAnalyzer struct {
+	Analyzer *analysis.Analyzer
+	Debug    bool
+}
 
-// This function checks if the given instance can be bound to the interface by the bind functions of Dingo.
-// example: injector.Bind(someInterface).To(mustImplementSomeInterface)
-func run(pass *analysis.Pass) (interface{}, error) {
-	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-	nodeFilter := []ast.Node{
-		(*ast.FuncDecl)(nil),
+// NewAnalyzer creates a new Analyzer which checks if a dingo binding to an interface really implements the interface.
+// Bindings which can't be verified without running the code are skipped, in debug mode the reason is logged.
+func NewAnalyzer(debug bool) *correctBindingAnalyzer {
+	analyzer := new(correctBindingAnalyzer)
+	analyzer.Debug = debug
+	analyzer.Analyzer = &analysis.Analyzer{
+		Name:     "checkCorrectInterfaceToInstanceBinding",
+		Doc:      "check if the Binding of an Interface to an Implementation with the Bind() -Function is possible",
+		Run:      analyzer.run,
+		Requires: []*analysis.Analyzer{BindingsAnalyzer},
 	}
+	return analyzer
+}
 
-	checkFunction := func(n ast.Node) {
-		funcdecl := n.(*ast.FuncDecl)
+// The functions which "bind" something
+var bindCalls = map[string]bool{"Bind": true, "BindMulti": true, "BindMap": true}
 
-		// Make sure we check functions with "*dingo.Injector" in the parameters
-		for _, param := range funcdecl.Type.Params.List {
-			if param, ok := param.Type.(*ast.StarExpr); ok {
-				if param, ok := param.X.(*ast.SelectorExpr); ok {
-					if isSelectorExprTypeOf(param, dingoTypeDecl) {
-						checkBlockStatmenetForCorrectBindings(funcdecl.Body, pass)
-					}
-				}
-			}
+// This function checks if the given instance can be bound to the interface by the bind functions of Dingo.
+// example: injector.Bind(someInterface).To(mustImplementSomeInterface)
+func (a *correctBindingAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
+	bindings := pass.ResultOf[BindingsAnalyzer].([]*Binding)
+	for _, binding := range checkedOnce(bindings) {
+		// Make sure the called function is one that "binds" something "to" something
+		if !bindCalls[binding.Kind] || !toCalls[binding.TargetKind] {
+			continue
+		}
+		if reason := checkBindingTarget(pass, binding, "Binding"); reason != "" {
+			log.Println(fmt.Sprintf("%s: skipped the binding check, %s", pass.Fset.Position(binding.BindCall.Pos()), reason), a.Debug)
 		}
 	}
-	input.Preorder(nodeFilter, checkFunction)
 	return nil, nil
 }
 
-// Checks a blockStatement like a function body for correct bindings
-func checkBlockStatmenetForCorrectBindings(block *ast.BlockStmt, pass *analysis.Pass) {
-	for _, stmt := range block.List {
-		if exp, ok := stmt.(*ast.ExprStmt); ok {
-			if call, ok := exp.X.(*ast.CallExpr); ok {
+// The functions which bind "to" a type or an instance
+var toCalls = map[string]bool{"To": true, "ToInstance": true}
 
-				// make sure we have a concatenated function
-				firstCall, _ := call.Fun.(*ast.SelectorExpr).X.(*ast.CallExpr)
-				secondCall := call
-				if firstCall == nil {
-					continue
-				}
-				firstFunc, _ := typeutil.Callee(pass.TypesInfo, firstCall).(*types.Func)
-				secondFunc, _ := typeutil.Callee(pass.TypesInfo, secondCall).(*types.Func)
-				if firstFunc == nil || secondFunc == nil {
-					continue
-				}
+// Checks that the target of the binding implements or is assignable to the bound type, kind names the binding in the messages, e.g. "Override".
+// Dingo resolves the types by reflection like this:
+// Bind() binds the type the passed pointer points to, To() creates new instances of the passed type without any pointers
+// and ToInstance() keeps the passed value as it is.
+// If the types are known only at runtime the binding can't be verified and the reason is returned.
+func checkBindingTarget(pass *analysis.Pass, binding *Binding, kind string) string {
+	bindType := pass.TypesInfo.TypeOf(binding.What)
+	toType := pass.TypesInfo.TypeOf(binding.Target)
+	if bindType == nil || toType == nil {
+		return "the bound type or the target is missing"
+	}
+	if types.IsInterface(bindType) {
+		return fmt.Sprintf("the bound type is only known at runtime from the %q", bindType.String())
+	}
+	bound := bindType
+	if pointer, ok := bindType.(*types.Pointer); ok {
+		bound = pointer.Elem()
+	}
+	if _, ok := bound.(*types.TypeParam); ok {
+		return fmt.Sprintf("the bound type is the type parameter %q, which is known only for the instances", bound.String())
+	}
+	var related []analysis.RelatedInformation
+	if named, ok := bound.(*types.Named); ok {
+		related = append(related, flanalysis.RelatedObject(named.Obj(), fmt.Sprintf("%q is declared here", named.Obj().Name())))
+	}
 
-				// Make sure we are using "flamingo.me/dingo"
-				if firstFunc.Pkg().Path() != dingoPkgPath || secondFunc.Pkg().Path() != dingoPkgPath {
-					continue
-				}
+	// Dingo panics on an untyped nil target as it has no type to check
+	if types.Identical(toType, types.Typ[types.UntypedNil]) {
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     binding.Target,
+			Message:  fmt.Sprintf("Nil %s! %s(nil) has no type, Dingo panics when binding it to %q", kind, binding.TargetKind, bindType.String()),
+			Category: "nil" + kind,
+			Related:  related,
+		})
+		return ""
+	}
 
-				// Make sure the called function is one that "binds" something "to" something
-				bindCalls := map[string]bool{"Bind": true, "BindMulti": true, "BindMap": true}
-				toCalls := map[string]bool{"To": true, "ToInstance": true}
-				if ok := bindCalls[firstFunc.Name()] && toCalls[secondFunc.Name()]; ok {
-					bindType := pass.TypesInfo.Types[firstCall.Args[0]].Type
-					toType := pass.TypesInfo.Types[secondCall.Args[0]].Type
-					// If struct literal is used, get the toType into the correct format
-					_, ok := toType.(*types.Named)
-					if ok {
-						toType = types.NewPointer(toType)
-					}
-					switch what := bindType.(*types.Pointer).Elem().(*types.Named).Underlying().(type) {
-					case *types.Interface:
-						// in case of interface to interface binding
-						to := toType.(*types.Pointer).Elem().Underlying()
-						if !types.Implements(toType, what) && !types.Implements(to, what) {
-							message := fmt.Sprintf("Incorrect Binding! %q must implement Interface %q", toType.Underlying().String(), bindType.Underlying().String())
-							flanalysis.Report(pass, message, secondCall.Args[0])
-						}
-					case *types.Signature:
-						if !types.AssignableTo(toType, what) {
-							message := fmt.Sprintf("Incorrect Binding! %q must have Signature of %q", toType.String(), what.String())
-							flanalysis.Report(pass, message, secondCall.Args[0])
-						}
-					default:
-						if !types.AssignableTo(toType, bindType) {
-							message := fmt.Sprintf("Incorrect Binding! %q must be assignable to %q", toType.String(), bindType.String())
-							flanalysis.Report(pass, message, secondCall.Args[0])
-						}
-					}
-				}
-			}
+	var fits bool
+	switch binding.TargetKind {
+	case "To":
+		target := toType
+		for pointer, ok := target.(*types.Pointer); ok; pointer, ok = target.(*types.Pointer) {
+			target = pointer.Elem()
 		}
+		if _, ok := target.(*types.TypeParam); ok {
+			return fmt.Sprintf("the target is the type parameter %q, which is known only for the instances", target.String())
+		}
+		fits = types.AssignableTo(target, bound) || types.AssignableTo(types.NewPointer(target), bound)
+	case "ToInstance":
+		fits = types.AssignableTo(toType, bound) || types.AssignableTo(toType, types.NewPointer(bound))
+	default:
+		return fmt.Sprintf("%s() is no binding to a type or an instance", binding.TargetKind)
+	}
+	if fits {
+		return ""
+	}
+	if types.IsInterface(toType) {
+		return fmt.Sprintf("the target is only known at runtime from the %q", toType.String())
+	}
+
+	// A struct is shown as the pointer To() creates or ToInstance() would need
+	shown := toType
+	if _, ok := toType.Underlying().(*types.Struct); ok {
+		shown = types.NewPointer(toType)
+	}
+	switch what := bound.Underlying().(type) {
+	case *types.Interface:
+		// ToInstance keeps a value as it is, unlike To() which creates a new instance behind a pointer
+		if shown != toType && binding.TargetKind == "ToInstance" && types.Implements(shown, what) {
+			reportValueBinding(pass, kind, binding.Target, toType, bindType, related)
+			return ""
+		}
+		reportIncorrectBinding(pass, kind, binding.Target, fmt.Sprintf("Incorrect %s! %q must implement Interface %q", kind, shown.String(), bindType.String()), related)
+	case *types.Signature:
+		reportIncorrectBinding(pass, kind, binding.Target, fmt.Sprintf("Incorrect %s! %q must have Signature of %q", kind, shown.String(), what.String()), related)
+	default:
+		reportIncorrectBinding(pass, kind, binding.Target, fmt.Sprintf("Incorrect %s! %q must be assignable to %q", kind, shown.String(), bindType.String()), related)
 	}
+	return ""
 }
 
-// checks if a given selectorExpression matches the given type (as string)
-// example: "dingo.Injector"
-func isSelectorExprTypeOf(expr *ast.SelectorExpr, typ string) bool {
-	if n, ok := expr.X.(*ast.Ident); ok {
-		fullName := n.Name + "." + expr.Sel.Name
-		if fullName == typ {
-			return true
+// Returns the bindings with their first occurrence only. The bindings of a generic helper function are collected once per instance,
+// checks of the code itself must report them once.
+func checkedOnce(bindings []*Binding) []*Binding {
+	var once []*Binding
+	seen := make(map[*ast.CallExpr]bool)
+	for _, binding := range bindings {
+		if !seen[binding.BindCall] {
+			seen[binding.BindCall] = true
+			once = append(once, binding)
 		}
 	}
-	return false
+	return once
+}
+
+// Reports a binding which is not possible, pointing to the bound type as well
+func reportIncorrectBinding(pass *analysis.Pass, kind string, to ast.Expr, message string, related []analysis.RelatedInformation) {
+	flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+		Node:     to,
+		Message:  message,
+		Category: "incorrect" + kind,
+		Related:  related,
+	})
+}
+
+// Reports a struct value bound by ToInstance to an interface which only the pointer implements,
+// for a literal the pointer is suggested instead
+func reportValueBinding(pass *analysis.Pass, kind string, to ast.Expr, value types.Type, bindType types.Type, related []analysis.RelatedInformation) {
+	diagnostic := flanalysis.Diagnostic{
+		Node:     to,
+		Message:  fmt.Sprintf("Value %s! %q is bound as value but only %q implements Interface %q, bind a pointer instead", kind, value.String(), types.NewPointer(value).String(), bindType.String()),
+		Category: "value" + kind,
+		Related:  related,
+	}
+	if _, ok := astutil.Unparen(to).(*ast.CompositeLit); ok {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Bind a pointer to the struct literal",
+			TextEdits: []analysis.TextEdit{{Pos: to.Pos(), End: to.Pos(), NewText: []byte("&")}},
+		}}
+	}
+	flanalysis.ReportDiagnostic(pass, diagnostic)
 }
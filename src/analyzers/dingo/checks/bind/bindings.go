@@ -0,0 +1,497 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"sort"
+
+	"flamingo.me/flamalyzer/src/flamalyzer/log"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var dingoPkgPath = "flamingo.me/dingo"
+
+// BindingsAnalyzer provides the normalized Bindings of all dingo binding calls for other analyzers.
+// It doesn't report anything on its own.
+var BindingsAnalyzer = &analysis.Analyzer{
+	Name:       "dingoBindings",
+	Doc:        "collect the dingo bindings for the binding checks",
+	Run:        runBindingsAnalyzer,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf(*new([]*Binding)),
+}
+
+// debugBindings is set by the flag "debug" of the BindingsAnalyzer, the calls which can't be resolved to a binding are logged then
+var debugBindings bool
+
+func init() {
+	BindingsAnalyzer.Flags.BoolVar(&debugBindings, "debug", false, "log the calls which can't be resolved to a binding")
+}
+
+// Binding is the normalized model of a dingo binding, which can be spread over a fluent call chain
+// or multiple statements using a variable.
+//
+// Example:
+// injector.Bind(new(I)).AnnotatedWith("x").In(dingo.Singleton).To(new(A))
+type Binding struct {
+	// BindCall is the call of the injector function (Bind, BindMulti, BindMap or Override)
+	BindCall *ast.CallExpr
+	// Kind is the name of the injector function
+	Kind string
+	// What is the argument of the injector function, e.g. `new(I)`
+	What ast.Expr
+	// Type is the type of What, usually a pointer to the bound type, e.g. `*I`.
+	// In a generic helper function the type parameters are replaced by the type arguments of the call, if known.
+	Type types.Type
+	// MapKey is the key of a BindMap call
+	MapKey ast.Expr
+
+	// AnnotationExpr is the annotation passed by AnnotatedWith or Override, Annotation holds its value if it is a constant
+	AnnotationExpr ast.Expr
+	Annotation     string
+
+	// TargetCall is the call of To, ToInstance or ToProvider, nil if the binding has no target
+	TargetCall *ast.CallExpr
+	// TargetKind is the name of the function of the TargetCall
+	TargetKind string
+	// Target is the argument of the TargetCall, TargetType its type with the type arguments like Type
+	Target     ast.Expr
+	TargetType types.Type
+
+	// ScopeExpr is the scope passed by In, Scope holds the name of the scope variable, e.g. "Singleton"
+	ScopeExpr ast.Expr
+	Scope     string
+	// Eager is set by AsEagerSingleton
+	Eager bool
+
+	// Function is the function the binding is declared in, usually the Configure method of a module.
+	// Bindings of helper functions belong to the function passing the injector on to them,
+	// bindings of function literals outside such a function to the enclosing function, nil at package level.
+	Function *types.Func
+}
+
+// The functions of the dingo.Injector which start a binding
+var bindingFunctions = map[string]bool{"Bind": true, "BindMulti": true, "BindMap": true, "Override": true}
+
+// Collects the bindings of all functions holding a *dingo.Injector, e.g. as parameter, as receiver or in a field of a parameter.
+// Helper functions of the package the injector is passed on to are followed, their bindings belong to the calling function.
+// Function literals holding an injector outside of such functions, e.g. in a list of module functions, are collected as well.
+func runBindingsAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// The functions of the package holding an injector in the order of their declaration
+	var holders []*types.Func
+	helpers := make(map[*types.Func]*ast.FuncDecl)
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		funcdecl := n.(*ast.FuncDecl)
+		fn, ok := pass.TypesInfo.Defs[funcdecl.Name].(*types.Func)
+		if !ok || funcdecl.Body == nil || !holdsInjector(fn.Type().(*types.Signature)) {
+			return
+		}
+		holders = append(holders, fn)
+		helpers[fn] = funcdecl
+	})
+
+	// Functions called by other functions holding an injector are helpers, they are followed from their callers
+	called := make(map[*types.Func]bool)
+	for _, fn := range holders {
+		ast.Inspect(helpers[fn].Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if callee := typeutil.StaticCallee(pass.TypesInfo, call); callee != nil && callee != fn && helpers[callee] != nil {
+					called[callee] = true
+				}
+			}
+			return true
+		})
+	}
+
+	var bindings []*Binding
+	unresolved := make(map[*ast.CallExpr]bool)
+	collected := make(map[*types.Func]bool)
+	collect := func(fn *types.Func) {
+		collector := newBindingCollector(pass, fn, helpers)
+		bindings = append(bindings, collector.collect(helpers[fn].Body)...)
+		for helper := range collector.followed {
+			collected[helper] = true
+		}
+		for call := range collector.unresolved {
+			unresolved[call] = true
+		}
+	}
+	for _, fn := range holders {
+		if !called[fn] {
+			collect(fn)
+		}
+	}
+	// Helpers only calling each other have no caller outside, they are collected on their own
+	for _, fn := range holders {
+		if !collected[fn] {
+			collect(fn)
+		}
+	}
+
+	nodeFilter = []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	input.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		// The body of a function holding an injector is collected already, including all function literals
+		if funcdecl, ok := n.(*ast.FuncDecl); ok {
+			fn, _ := pass.TypesInfo.Defs[funcdecl.Name].(*types.Func)
+			return helpers[fn] == nil
+		}
+		funclit := n.(*ast.FuncLit)
+		signature, ok := pass.TypesInfo.TypeOf(funclit).(*types.Signature)
+		if !ok || !holdsInjector(signature) {
+			return true
+		}
+		var fn *types.Func
+		for _, node := range stack {
+			if funcdecl, ok := node.(*ast.FuncDecl); ok {
+				fn, _ = pass.TypesInfo.Defs[funcdecl.Name].(*types.Func)
+			}
+		}
+		collector := newBindingCollector(pass, fn, helpers)
+		bindings = append(bindings, collector.collect(funclit.Body)...)
+		for call := range collector.unresolved {
+			unresolved[call] = true
+		}
+		return false
+	})
+
+	// The checks skip these calls, so it is logged why they don't find anything there
+	var skipped []*ast.CallExpr
+	for call := range unresolved {
+		skipped = append(skipped, call)
+	}
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].Pos() < skipped[j].Pos()
+	})
+	for _, call := range skipped {
+		log.Println(fmt.Sprintf("%s: skipped the call of %s, a binding function called through a function value can't be resolved",
+			pass.Fset.Position(call.Pos()), types.ExprString(call.Fun)), debugBindings)
+	}
+	return bindings, nil
+}
+
+// bindingCollector walks all statements of a function body and merges the calls belonging to the same binding
+type bindingCollector struct {
+	pass     *analysis.Pass
+	function *types.Func
+	bindings []*Binding
+	// calls which already are part of a processed chain
+	visited map[*ast.CallExpr]bool
+	// variables holding a binding, e.g. `b := injector.Bind(new(I))`
+	variables map[types.Object]*Binding
+	// helpers are the functions of the package holding an injector, followed are the helpers already collected
+	helpers  map[*types.Func]*ast.FuncDecl
+	followed map[*types.Func]bool
+	// typeArgs are the type arguments of the generic helper function being collected,
+	// instances are the instances of generic helpers already collected, e.g. `bindRepository[Product]`
+	typeArgs  typeArgs
+	instances map[string]bool
+	// unresolved are the calls returning a *dingo.Binding whose function is not known statically, e.g. `bind := injector.Bind`
+	unresolved map[*ast.CallExpr]bool
+}
+
+func newBindingCollector(pass *analysis.Pass, function *types.Func, helpers map[*types.Func]*ast.FuncDecl) *bindingCollector {
+	return &bindingCollector{
+		pass:       pass,
+		function:   function,
+		visited:    make(map[*ast.CallExpr]bool),
+		variables:  make(map[types.Object]*Binding),
+		helpers:    helpers,
+		followed:   map[*types.Func]bool{function: true},
+		instances:  make(map[string]bool),
+		unresolved: make(map[*ast.CallExpr]bool),
+	}
+}
+
+// Returns the bindings of the given function body in the order of their appearance
+func (c *bindingCollector) collect(body *ast.BlockStmt) []*Binding {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == len(node.Rhs) {
+				for i := range node.Rhs {
+					c.assign(node.Lhs[i], node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) == len(node.Values) {
+				for i := range node.Values {
+					c.assign(node.Names[i], node.Values[i])
+				}
+			}
+		case *ast.CallExpr:
+			c.chain(node)
+			c.follow(node)
+			if c.isUnresolved(node) {
+				c.unresolved[node] = true
+			}
+		}
+		return true
+	})
+	return c.bindings
+}
+
+// Checks if the call returns a *dingo.Binding but its function is not known statically, e.g. a function value
+func (c *bindingCollector) isUnresolved(call *ast.CallExpr) bool {
+	if _, ok := typeutil.Callee(c.pass.TypesInfo, call).(*types.Func); ok {
+		return false
+	}
+	pointer, ok := c.pass.TypesInfo.TypeOf(call).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := pointer.Elem().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == dingoPkgPath && named.Obj().Name() == "Binding"
+}
+
+// Collects the bindings of a helper function the injector is passed on to, each helper is collected once per function.
+// Generic helpers are collected once per instance with their type arguments, but not while they are collected already.
+func (c *bindingCollector) follow(call *ast.CallExpr) {
+	callee := typeutil.StaticCallee(c.pass.TypesInfo, call)
+	if callee == nil || c.helpers[callee] == nil {
+		return
+	}
+	args := callTypeArgs(c.pass, call, callee, c.typeArgs)
+	if args == nil {
+		if !c.followed[callee] {
+			c.followed[callee] = true
+			c.collect(c.helpers[callee].Body)
+		}
+		return
+	}
+	instance := callee.FullName()
+	params := callee.Type().(*types.Signature).TypeParams()
+	for i := 0; i < params.Len(); i++ {
+		instance += " " + types.TypeString(args[params.At(i)], nil)
+	}
+	if c.instances[instance] || c.typeArgs[params.At(0)] != nil {
+		return
+	}
+	c.instances[instance] = true
+	c.followed[callee] = true
+
+	// The calls of the helper are processed again with the type arguments of this instance,
+	// the type arguments of the calling helpers are kept to recognize recursive instances
+	helper := newBindingCollector(c.pass, c.function, c.helpers)
+	helper.followed, helper.instances, helper.typeArgs = c.followed, c.instances, args
+	for param, arg := range c.typeArgs {
+		helper.typeArgs[param] = arg
+	}
+	c.bindings = append(c.bindings, helper.collect(c.helpers[callee].Body)...)
+}
+
+// Remembers the binding a variable is assigned to
+func (c *bindingCollector) assign(lhs ast.Expr, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return
+	}
+	call, ok := astutil.Unparen(rhs).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if binding := c.chain(call); binding != nil {
+		if obj := c.pass.TypesInfo.ObjectOf(ident); obj != nil {
+			c.variables[obj] = binding
+		}
+	}
+}
+
+// Processes the fluent call chain ending with the given call and returns the binding it belongs to
+func (c *bindingCollector) chain(outermost *ast.CallExpr) *Binding {
+	if c.visited[outermost] {
+		return nil
+	}
+
+	// Walk down the chain: injector.Bind(x).In(y).To(z) consists of the calls To, In and Bind
+	var calls []*ast.CallExpr
+	var funcs []*types.Func
+	var binding *Binding
+	expr := ast.Expr(outermost)
+	for {
+		call, ok := astutil.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			// The chain might continue a binding stored in a variable
+			if ident, ok := astutil.Unparen(expr).(*ast.Ident); ok {
+				binding = c.variables[c.pass.TypesInfo.ObjectOf(ident)]
+			}
+			break
+		}
+		fn := dingoFunc(c.pass, call)
+		if fn == nil {
+			break
+		}
+		calls = append(calls, call)
+		funcs = append(funcs, fn)
+		if isInjectorFunc(fn) {
+			if bindingFunctions[fn.Name()] {
+				binding = c.newBinding(call, fn)
+			}
+			break
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		expr = selector.X
+	}
+	for _, call := range calls {
+		c.visited[call] = true
+	}
+	if binding == nil {
+		return nil
+	}
+
+	// Apply the calls in the order they are executed
+	for i := len(calls) - 1; i >= 0; i-- {
+		if isInjectorFunc(funcs[i]) {
+			continue
+		}
+		c.apply(binding, calls[i], funcs[i])
+	}
+	return binding
+}
+
+// Creates a binding from the call of an injector function
+func (c *bindingCollector) newBinding(call *ast.CallExpr, fn *types.Func) *Binding {
+	binding := &Binding{BindCall: call, Kind: fn.Name(), Function: c.function}
+	if len(call.Args) > 0 {
+		binding.What = call.Args[0]
+		binding.Type = c.typeArgs.substitute(c.pass.TypesInfo.TypeOf(call.Args[0]))
+	}
+	if len(call.Args) > 1 {
+		switch fn.Name() {
+		case "BindMap":
+			binding.MapKey = call.Args[1]
+		case "Override":
+			c.annotate(binding, call.Args[1])
+		}
+	}
+	c.bindings = append(c.bindings, binding)
+	return binding
+}
+
+// Applies a call of a *dingo.Binding function to the binding
+func (c *bindingCollector) apply(binding *Binding, call *ast.CallExpr, fn *types.Func) {
+	var arg ast.Expr
+	if len(call.Args) > 0 {
+		arg = call.Args[0]
+	}
+	switch fn.Name() {
+	case "To", "ToInstance", "ToProvider":
+		binding.TargetCall = call
+		binding.TargetKind = fn.Name()
+		binding.Target = arg
+		binding.TargetType = nil
+		if arg != nil {
+			binding.TargetType = c.typeArgs.substitute(c.pass.TypesInfo.TypeOf(arg))
+		}
+	case "AnnotatedWith":
+		c.annotate(binding, arg)
+	case "In":
+		binding.ScopeExpr = arg
+		binding.Scope = scopeName(arg)
+	case "AsEagerSingleton":
+		binding.Scope = "Singleton"
+		binding.Eager = true
+	}
+}
+
+// Sets the annotation of a binding, the value is known only for constants
+func (c *bindingCollector) annotate(binding *Binding, expr ast.Expr) {
+	binding.AnnotationExpr = expr
+	binding.Annotation = ""
+	if expr == nil {
+		return
+	}
+	if value := c.pass.TypesInfo.Types[expr].Value; value != nil && value.Kind() == constant.String {
+		binding.Annotation = constant.StringVal(value)
+	}
+}
+
+// Returns the name of a scope variable like `dingo.Singleton`
+func scopeName(expr ast.Expr) string {
+	switch scope := astutil.Unparen(expr).(type) {
+	case *ast.SelectorExpr:
+		return scope.Sel.Name
+	case *ast.Ident:
+		return scope.Name
+	}
+	return ""
+}
+
+// Returns the called function if it is a method of "flamingo.me/dingo"
+func dingoFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != dingoPkgPath {
+		return nil
+	}
+	if fn.Type().(*types.Signature).Recv() == nil {
+		return nil
+	}
+	return fn
+}
+
+// Checks if the function is a method of the dingo.Injector
+func isInjectorFunc(fn *types.Func) bool {
+	recv := fn.Type().(*types.Signature).Recv().Type()
+	if pointer, ok := recv.(*types.Pointer); ok {
+		recv = pointer.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	return ok && named.Obj().Name() == "Injector"
+}
+
+// Checks if the receiver or a parameter of the function holds a *dingo.Injector
+func holdsInjector(signature *types.Signature) bool {
+	if recv := signature.Recv(); recv != nil && holdsInjectorType(recv.Type(), make(map[types.Type]bool)) {
+		return true
+	}
+	for i := 0; i < signature.Params().Len(); i++ {
+		if holdsInjectorType(signature.Params().At(i).Type(), make(map[types.Type]bool)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if the type is a *dingo.Injector or a struct, or a pointer to a struct, with a field holding one
+func holdsInjectorType(typ types.Type, seen map[types.Type]bool) bool {
+	if isInjector(typ) {
+		return true
+	}
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+	structType, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < structType.NumFields(); i++ {
+		if holdsInjectorType(structType.Field(i).Type(), seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+// CycleAnalyzer checks that the dependencies injected by dingo don't form a cycle.
+// Dingo detects such a cycle only at runtime, when the first instance of the cycle is requested.
+var CycleAnalyzer = &analysis.Analyzer{
+	Name:     "checkDependencyCycle",
+	Doc:      "check that injected dependencies don't form a cycle, inject a Provider func() T to break a cycle",
+	Run:      runCycleAnalyzer,
+	Requires: []*analysis.Analyzer{programAnalyzer},
+}
+
+// The graph of the types dingo creates, an edge leads from a type to a type it needs
+type dependencyGraph struct {
+	nodes []string
+	edges map[string][]dependency
+}
+
+func newDependencyGraph(dependencies []dependency) *dependencyGraph {
+	graph := &dependencyGraph{edges: make(map[string][]dependency)}
+	known := make(map[string]bool)
+	addNode := func(node string) {
+		if !known[node] {
+			known[node] = true
+			graph.nodes = append(graph.nodes, node)
+		}
+	}
+	for _, dependency := range dependencies {
+		if dependency.From == "" || dependency.Collection != "" {
+			continue
+		}
+		addNode(dependency.From)
+		addNode(dependency.To)
+		graph.edges[dependency.From] = append(graph.edges[dependency.From], dependency)
+	}
+	return graph
+}
+
+// This function builds the dependency graph of all visible packages and reports one cycle for every strongly connected component.
+// Dependencies on a Provider `func() T` are no edges of the graph, as dingo creates T only when the Provider is called.
+func runCycleAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	program := pass.ResultOf[programAnalyzer].(*program)
+	graph := newDependencyGraph(program.dependencies)
+
+	for _, component := range graph.components() {
+		cycle := graph.cycle(component)
+		if cycle == nil {
+			continue
+		}
+		ids := make([]string, len(cycle))
+		steps := make([]string, len(cycle))
+		for i, dependency := range cycle {
+			ids[i] = dependency.id()
+			steps[i] = fmt.Sprintf("%q needs %q by %s (%s)", dependency.FromName, dependency.ToName, dependency.Description, dependency.Location)
+		}
+		// The cycle has been reported in the imported package already
+		if program.DeclaredByImport(ids...) {
+			continue
+		}
+		if node := cycleNode(pass, program, ids); node != nil {
+			flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+				Node:     node,
+				Message:  fmt.Sprintf("Dependency Cycle! %s, inject a Provider func() T to break the cycle", strings.Join(steps, ", ")),
+				Category: "dependencyCycle",
+			})
+		}
+	}
+	return nil, nil
+}
+
+// Returns the first dependency of the cycle declared in this package, otherwise the import bringing in the cycle
+func cycleNode(pass *analysis.Pass, program *program, ids []string) ast.Node {
+	for _, id := range ids {
+		if node, ok := program.localDependencies[id]; ok {
+			return node
+		}
+	}
+	for _, id := range ids {
+		if node := program.ImportSpec(pass, id); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+// Returns the strongly connected components of the graph by Tarjan's algorithm
+func (g *dependencyGraph) components() [][]string {
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var connect func(node string)
+	connect = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+		for _, edge := range g.edges[node] {
+			if _, visited := index[edge.To]; !visited {
+				connect(edge.To)
+				if lowlink[edge.To] < lowlink[node] {
+					lowlink[node] = lowlink[edge.To]
+				}
+			} else if onStack[edge.To] && index[edge.To] < lowlink[node] {
+				lowlink[node] = index[edge.To]
+			}
+		}
+		if lowlink[node] != index[node] {
+			return
+		}
+		var component []string
+		for {
+			member := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[member] = false
+			component = append(component, member)
+			if member == node {
+				break
+			}
+		}
+		components = append(components, component)
+	}
+	for _, node := range g.nodes {
+		if _, visited := index[node]; !visited {
+			connect(node)
+		}
+	}
+	return components
+}
+
+// Returns the shortest cycle through the first node of the component, nil if the component is no cycle
+func (g *dependencyGraph) cycle(component []string) []dependency {
+	members := make(map[string]bool)
+	for _, node := range component {
+		members[node] = true
+	}
+	var start string
+	for _, node := range g.nodes {
+		if members[node] {
+			start = node
+			break
+		}
+	}
+
+	// Breadth-first search from the start back to the start, remembering the edge each node was reached by
+	reachedBy := make(map[string]dependency)
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range g.edges[node] {
+			if !members[edge.To] {
+				continue
+			}
+			if edge.To == start {
+				cycle := []dependency{edge}
+				for edge.From != start {
+					edge = reachedBy[edge.From]
+					cycle = append([]dependency{edge}, cycle...)
+				}
+				return cycle
+			}
+			if _, reached := reachedBy[edge.To]; !reached {
+				reachedBy[edge.To] = edge
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+	return nil
+}
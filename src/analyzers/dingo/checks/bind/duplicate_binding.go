@@ -0,0 +1,103 @@
+package bind
+
+import (
+	"fmt"
+	"strconv"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+// DuplicateAnalyzer checks that a type is bound only once per annotation over all modules.
+// Dingo refuses conflicting bindings when the modules get initialized.
+var DuplicateAnalyzer = &analysis.Analyzer{
+	Name:     "checkDuplicateBinding",
+	Doc:      "check that a type is bound only once per annotation, use the Override() -Function to replace the Binding of another module",
+	Run:      runDuplicateAnalyzer,
+	Requires: []*analysis.Analyzer{programAnalyzer},
+}
+
+// This function reports bindings of the same type and annotation which dingo gets together.
+// Bindings of the same module are reported in its package. Bindings of different modules conflict only if the modules
+// are installed by the same injector, which is known in the main packages: a type bound to A in one module and to B
+// in another module is reported there if both are passed to dingo.NewInjector or reached by Depends().
+// Bindings outside of modules can't be assigned to an injector, they are compared within their function only.
+func runDuplicateAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	program := pass.ResultOf[programAnalyzer].(*program)
+	installed := program.installedTogether()
+
+	// An Override replaces all bindings of the type and annotation
+	overridden := make(map[string]bool)
+	var keys []string
+	groups := make(map[string][]boundType)
+	for _, bound := range program.bindings {
+		if !bound.single() {
+			continue
+		}
+		if bound.Kind == "Override" {
+			overridden[bound.key()] = true
+			continue
+		}
+		if groups[bound.key()] == nil {
+			keys = append(keys, bound.key())
+		}
+		groups[bound.key()] = append(groups[bound.key()], bound)
+	}
+
+	for _, key := range keys {
+		if overridden[key] {
+			continue
+		}
+		group := groups[key]
+		for j := 1; j < len(group); j++ {
+			for i := 0; i < j; i++ {
+				if group[i].equal(group[j]) || program.DeclaredByImport(group[i].id(), group[j].id()) {
+					continue
+				}
+				if !sameUnit(group[i], group[j]) && !installed(group[i].Module, group[j].Module) {
+					continue
+				}
+				reportDuplicateBinding(pass, program, group[i], group[j])
+				break
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Checks if both bindings are declared by the same module, or by the same function outside of modules
+func sameUnit(first boundType, second boundType) bool {
+	if first.Module != "" || second.Module != "" {
+		return first.Module == second.Module
+	}
+	return first.Package == second.Package && first.Function == second.Function
+}
+
+func reportDuplicateBinding(pass *analysis.Pass, program *program, first boundType, second boundType) {
+	name := strconv.Quote(second.Name)
+	if second.Annotation != "" {
+		name += fmt.Sprintf(" annotated with %q", second.Annotation)
+	}
+	reportBindingPair(pass, program, first, second, flanalysis.Diagnostic{
+		Message:  fmt.Sprintf("Duplicate Binding! %s is bound in %s and in %s, use Override() to replace a Binding", name, first, second),
+		Category: "duplicateBinding",
+	})
+}
+
+// Reports the second binding if it is declared in this package, otherwise the first one.
+// If both are declared in imported packages, the import bringing in the second one is reported.
+func reportBindingPair(pass *analysis.Pass, program *program, first boundType, second boundType, diagnostic flanalysis.Diagnostic) {
+	if binding, ok := program.localBindings[second.id()]; ok {
+		diagnostic.Node = binding.BindCall
+		if firstBinding, ok := program.localBindings[first.id()]; ok {
+			diagnostic.Related = append(diagnostic.Related, flanalysis.RelatedNode(firstBinding.BindCall, fmt.Sprintf("%q is bound here first", second.Name)))
+		}
+	} else if binding, ok := program.localBindings[first.id()]; ok {
+		diagnostic.Node = binding.BindCall
+	} else {
+		diagnostic.Node = program.ImportSpec(pass, second.id())
+	}
+	if diagnostic.Node != nil {
+		flanalysis.ReportDiagnostic(pass, diagnostic)
+	}
+}
@@ -0,0 +1,134 @@
+package bind
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// typeArgs maps the type parameters of a generic function to the type arguments of a call, e.g. T to Product for `bindRepository[Product](injector)`
+type typeArgs map[*types.TypeParam]types.Type
+
+// Returns the type arguments of the call of a generic function, the arguments are resolved with the type arguments of the calling function.
+// Nil is returned if the callee is not generic.
+func callTypeArgs(pass *analysis.Pass, call *ast.CallExpr, callee *types.Func, outer typeArgs) typeArgs {
+	params := callee.Type().(*types.Signature).TypeParams()
+	if params.Len() == 0 {
+		return nil
+	}
+	var ident *ast.Ident
+	switch fun := astutil.Unparen(call.Fun).(type) {
+	case *ast.IndexExpr:
+		ident = calleeIdent(fun.X)
+	case *ast.IndexListExpr:
+		ident = calleeIdent(fun.X)
+	default:
+		ident = calleeIdent(fun)
+	}
+	instance, ok := pass.TypesInfo.Instances[ident]
+	if ident == nil || !ok || instance.TypeArgs.Len() != params.Len() {
+		return nil
+	}
+	args := make(typeArgs)
+	for i := 0; i < params.Len(); i++ {
+		args[params.At(i)] = outer.substitute(instance.TypeArgs.At(i))
+	}
+	return args
+}
+
+// Returns the identifier of a called function, e.g. `f` of `pkg.f`
+func calleeIdent(expr ast.Expr) *ast.Ident {
+	switch fun := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	}
+	return nil
+}
+
+// Replaces the type parameters in the type by their type arguments, types without type parameters are returned as they are
+func (args typeArgs) substitute(typ types.Type) types.Type {
+	if len(args) == 0 || !mentionsTypeParam(typ) {
+		return typ
+	}
+	switch t := typ.(type) {
+	case *types.TypeParam:
+		if arg, ok := args[t]; ok {
+			return arg
+		}
+	case *types.Pointer:
+		return types.NewPointer(args.substitute(t.Elem()))
+	case *types.Slice:
+		return types.NewSlice(args.substitute(t.Elem()))
+	case *types.Array:
+		return types.NewArray(args.substitute(t.Elem()), t.Len())
+	case *types.Map:
+		return types.NewMap(args.substitute(t.Key()), args.substitute(t.Elem()))
+	case *types.Chan:
+		return types.NewChan(t.Dir(), args.substitute(t.Elem()))
+	case *types.Signature:
+		return types.NewSignatureType(nil, nil, nil, args.substituteTuple(t.Params()), args.substituteTuple(t.Results()), t.Variadic())
+	case *types.Named:
+		targs := make([]types.Type, t.TypeArgs().Len())
+		for i := range targs {
+			targs[i] = args.substitute(t.TypeArgs().At(i))
+		}
+		if instance, err := types.Instantiate(nil, t.Origin(), targs, false); err == nil {
+			return instance
+		}
+	}
+	return typ
+}
+
+func (args typeArgs) substituteTuple(tuple *types.Tuple) *types.Tuple {
+	vars := make([]*types.Var, tuple.Len())
+	for i := range vars {
+		v := tuple.At(i)
+		vars[i] = types.NewParam(v.Pos(), v.Pkg(), v.Name(), args.substitute(v.Type()))
+	}
+	return types.NewTuple(vars...)
+}
+
+// Checks if the type is a type parameter or is composed of one, e.g. `*Repository[T]`.
+// Such types are known only for the instances of the generic function or type.
+func mentionsTypeParam(typ types.Type) bool {
+	switch t := typ.(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return mentionsTypeParam(t.Elem())
+	case *types.Slice:
+		return mentionsTypeParam(t.Elem())
+	case *types.Array:
+		return mentionsTypeParam(t.Elem())
+	case *types.Map:
+		return mentionsTypeParam(t.Key()) || mentionsTypeParam(t.Elem())
+	case *types.Chan:
+		return mentionsTypeParam(t.Elem())
+	case *types.Signature:
+		return tupleMentionsTypeParam(t.Params()) || tupleMentionsTypeParam(t.Results())
+	case *types.Named:
+		// The generic type itself, e.g. the receiver type of a generic method
+		if t.TypeParams().Len() > 0 && t.TypeArgs().Len() == 0 {
+			return true
+		}
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			if mentionsTypeParam(t.TypeArgs().At(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func tupleMentionsTypeParam(tuple *types.Tuple) bool {
+	for i := 0; i < tuple.Len(); i++ {
+		if mentionsTypeParam(tuple.At(i).Type()) {
+			return true
+		}
+	}
+	return false
+}
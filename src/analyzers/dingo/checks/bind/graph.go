@@ -0,0 +1,197 @@
+package bind
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	"golang.org/x/tools/go/analysis"
+)
+
+// GraphAnalyzer provides the dingo modules, bindings and injection points of a package,
+// main packages include those of all packages they import.
+// It doesn't report anything, the result is used to export the wiring of an application, e.g. for a review.
+var GraphAnalyzer = &analysis.Analyzer{
+	Name:       "dingoGraph",
+	Doc:        "collect the dingo modules, bindings and injection points of a package, and of all imported packages for main packages, as graph",
+	Run:        runGraphAnalyzer,
+	Requires:   []*analysis.Analyzer{programAnalyzer},
+	ResultType: reflect.TypeOf(new(Graph)),
+}
+
+// Graph is the static model of the dingo wiring, types and functions are identified by their full name
+type Graph struct {
+	Modules    []GraphModule    `json:"modules"`
+	Bindings   []GraphBinding   `json:"bindings"`
+	Injections []GraphInjection `json:"injections"`
+}
+
+// GraphModule is a type with a Configure(*dingo.Injector) method
+type GraphModule struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Package  string `json:"package"`
+	Location string `json:"location"`
+	// Depends are the full names of the modules returned by the Depends() method
+	Depends []string `json:"depends,omitempty"`
+}
+
+// GraphBinding is a binding of a type to a target
+type GraphBinding struct {
+	// Kind is the injector function, e.g. "Bind" or "BindMulti"
+	Kind       string `json:"kind"`
+	Package    string `json:"package"`
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Annotation string `json:"annotation,omitempty"`
+	MapKey     string `json:"mapKey,omitempty"`
+	// TargetKind is the function binding the target, e.g. "To" or "ToProvider", empty if the binding has no target.
+	// Target is the full name of the created type or the provider function, empty if it has none, e.g. for a function literal.
+	TargetKind string `json:"targetKind,omitempty"`
+	Target     string `json:"target,omitempty"`
+	TargetName string `json:"targetName,omitempty"`
+	Scope      string `json:"scope,omitempty"`
+	Eager      bool   `json:"eager,omitempty"`
+	// Module is the full name of the module declaring the binding, empty if it is declared outside of a Configure method
+	Module   string `json:"module,omitempty"`
+	Function string `json:"function"`
+	Location string `json:"location"`
+}
+
+// GraphInjection is a dependency injected by dingo, e.g. a parameter of an Inject method
+type GraphInjection struct {
+	// From is the type the dependency is injected into, empty for the parameters of a provider of an annotated binding
+	From     string `json:"from,omitempty"`
+	FromName string `json:"fromName,omitempty"`
+	To       string `json:"to"`
+	ToName   string `json:"toName"`
+	// Collection is "[]" or "map[string]" if a slice or map of To is injected
+	Collection  string `json:"collection,omitempty"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+}
+
+// Converts the program, i.e. everything known in this package, into the graph
+func runGraphAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	program := pass.ResultOf[programAnalyzer].(*program)
+	graph := new(Graph)
+	for _, module := range program.modules {
+		graphModule := GraphModule{
+			Type:     module.Type,
+			Name:     module.Name,
+			Package:  module.Package,
+			Location: graphLocation(module.Location),
+		}
+		for _, dependency := range module.Depends {
+			graphModule.Depends = append(graphModule.Depends, dependency.To)
+		}
+		graph.Modules = append(graph.Modules, graphModule)
+	}
+	for _, bound := range program.bindings {
+		// Dingo binds the injector itself, which is no part of the wiring of the application
+		if bound.Package == dingoPkgPath {
+			continue
+		}
+		graphBinding := GraphBinding{
+			Kind:       bound.Kind,
+			Package:    bound.Package,
+			Type:       bound.Type,
+			Name:       bound.Name,
+			Annotation: bound.Annotation,
+			TargetKind: bound.TargetKind,
+			Target:     bound.TargetType,
+			TargetName: bound.TargetName,
+			Scope:      bound.Scope,
+			Eager:      bound.Eager,
+			Module:     bound.Module,
+			Function:   bound.Function,
+			Location:   graphLocation(bound.Location),
+		}
+		if bound.MapKeyKnown {
+			graphBinding.MapKey = bound.MapKey
+		}
+		graph.Bindings = append(graph.Bindings, graphBinding)
+	}
+	for _, dependency := range program.dependencies {
+		if !dependency.Injected {
+			continue
+		}
+		graph.Injections = append(graph.Injections, GraphInjection{
+			From:        dependency.From,
+			FromName:    dependency.FromName,
+			To:          dependency.To,
+			ToName:      dependency.ToName,
+			Collection:  dependency.Collection,
+			Description: dependency.Description,
+			Location:    graphLocation(dependency.Location),
+		})
+	}
+	return graph, nil
+}
+
+// Merges the other graph into this one, e.g. the graphs of several applications. Elements known already are skipped.
+func (g *Graph) Merge(other *Graph) {
+	known := make(map[string]bool)
+	for _, module := range g.Modules {
+		known[module.Location] = true
+	}
+	for _, binding := range g.Bindings {
+		known[binding.id()] = true
+	}
+	for _, injection := range g.Injections {
+		known[injection.id()] = true
+	}
+	for _, module := range other.Modules {
+		if !known[module.Location] {
+			known[module.Location] = true
+			g.Modules = append(g.Modules, module)
+		}
+	}
+	for _, binding := range other.Bindings {
+		if !known[binding.id()] {
+			known[binding.id()] = true
+			g.Bindings = append(g.Bindings, binding)
+		}
+	}
+	for _, injection := range other.Injections {
+		if !known[injection.id()] {
+			known[injection.id()] = true
+			g.Injections = append(g.Injections, injection)
+		}
+	}
+}
+
+// RelativeTo replaces the file names of the locations by paths relative to the directory, e.g. the working directory
+func (g *Graph) RelativeTo(dir string) {
+	relative := func(location string) string {
+		if rel, err := filepath.Rel(dir, location); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+		return location
+	}
+	for i := range g.Modules {
+		g.Modules[i].Location = relative(g.Modules[i].Location)
+	}
+	for i := range g.Bindings {
+		g.Bindings[i].Location = relative(g.Bindings[i].Location)
+	}
+	for i := range g.Injections {
+		g.Injections[i].Location = relative(g.Injections[i].Location)
+	}
+}
+
+// A location might declare several injections, e.g. all parameters of a provider
+// The type is part of the id, as a binding of a generic helper function binds a type per instance
+func (b GraphBinding) id() string {
+	return b.Location + " " + b.Type
+}
+
+func (i GraphInjection) id() string {
+	return i.Location + " " + i.To + " " + i.Description
+}
+
+func graphLocation(l facts.Location) string {
+	return fmt.Sprintf("%s:%d:%d", l.Filename, l.Line, l.Column)
+}
@@ -0,0 +1,108 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// InterceptorAnalyzer checks that an interceptor bound by BindInterceptor can wrap the intercepted interface
+var InterceptorAnalyzer = &analysis.Analyzer{
+	Name:     "checkCorrectInterceptorBinding",
+	Doc:      "check if the interceptor of a BindInterceptor() call is a struct embedding the intercepted interface as its first field and overrides its methods with the same signature",
+	Run:      runInterceptorAnalyzer,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// This function checks the calls of BindInterceptor.
+// Dingo creates a new instance of the interceptor for every intercepted instance and assigns the intercepted instance to its first field.
+// example: injector.BindInterceptor(new(Interface), LoggingInterceptor{})
+func runInterceptorAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn := dingoFunc(pass, call)
+		if fn == nil || !isInjectorFunc(fn) || fn.Name() != "BindInterceptor" || len(call.Args) != 2 {
+			return
+		}
+		checkInterceptor(pass, call.Args[0], call.Args[1])
+	})
+	return nil, nil
+}
+
+// Checks the intercepted interface and the interceptor of a BindInterceptor call
+func checkInterceptor(pass *analysis.Pass, to ast.Expr, interceptor ast.Expr) {
+	toType := pass.TypesInfo.TypeOf(to)
+	interceptorType := pass.TypesInfo.TypeOf(interceptor)
+	// Values passed as interface{} are only known at runtime, other interfaces can't hold a pointer to an interface
+	if toType == nil || interceptorType == nil || isEmptyInterface(toType) || types.IsInterface(interceptorType) {
+		return
+	}
+
+	// Dingo intercepts the interface the pointer points to
+	pointer, ok := toType.(*types.Pointer)
+	if !ok || !types.IsInterface(pointer.Elem()) {
+		reportIncorrectInterceptor(pass, to, fmt.Sprintf("Incorrect Interceptor! %q must be a pointer to an interface, e.g. new(Interface)", toType.String()))
+		return
+	}
+	intercepted := pointer.Elem()
+
+	if _, ok := interceptorType.(*types.Pointer); ok {
+		reportIncorrectInterceptor(pass, interceptor, fmt.Sprintf("Incorrect Interceptor! %q must be a struct value, Dingo creates a new instance for every intercepted %q", interceptorType.String(), intercepted.String()))
+		return
+	}
+	structType, ok := interceptorType.Underlying().(*types.Struct)
+	if !ok {
+		reportIncorrectInterceptor(pass, interceptor, fmt.Sprintf("Incorrect Interceptor! %q must be a struct embedding %q", interceptorType.String(), intercepted.String()))
+		return
+	}
+
+	// The intercepted instance is assigned to the first field
+	if structType.NumFields() == 0 {
+		reportIncorrectInterceptor(pass, interceptor, fmt.Sprintf("Incorrect Interceptor! %q must embed %q as its first field", interceptorType.String(), intercepted.String()))
+		return
+	}
+	if first := structType.Field(0); !first.Embedded() || !types.Identical(first.Type(), intercepted) {
+		reportIncorrectInterceptor(pass, interceptor,
+			fmt.Sprintf("Incorrect Interceptor! %q must embed %q as its first field instead of %q", interceptorType.String(), intercepted.String(), first.Type().String()),
+			flanalysis.RelatedObject(first, fmt.Sprintf("%q must be an embedded %q", first.Name(), intercepted.String())))
+		return
+	}
+
+	// A method of the interceptor with another signature hides the method of the embedded interface
+	iface := intercepted.Underlying().(*types.Interface)
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		obj, index, _ := types.LookupFieldOrMethod(types.NewPointer(interceptorType), true, method.Pkg(), method.Name())
+		override, ok := obj.(*types.Func)
+		if !ok || len(index) != 1 || types.Identical(override.Type(), method.Type()) {
+			continue
+		}
+		reportIncorrectInterceptor(pass, interceptor,
+			fmt.Sprintf("Incorrect Interceptor! Method %q of %q must have the Signature %q of %q", method.Name(), interceptorType.String(), method.Type().String(), intercepted.String()),
+			flanalysis.RelatedObject(override, fmt.Sprintf("%q is declared here", method.Name())))
+	}
+}
+
+// Checks if the type is an interface without methods, which can hold a value of any type
+func isEmptyInterface(typ types.Type) bool {
+	iface, ok := typ.Underlying().(*types.Interface)
+	return ok && iface.NumMethods() == 0
+}
+
+func reportIncorrectInterceptor(pass *analysis.Pass, node ast.Node, message string, related ...analysis.RelatedInformation) {
+	flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+		Node:     node,
+		Message:  message,
+		Category: "incorrectInterceptor",
+		Related:  related,
+	})
+}
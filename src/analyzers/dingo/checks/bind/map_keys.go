@@ -0,0 +1,91 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"strconv"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+// MapKeyAnalyzer checks the keys of BindMap bindings.
+// Dingo silently replaces a map binding by a later one with the same key, so the keys must be unique per type and annotation.
+var MapKeyAnalyzer = &analysis.Analyzer{
+	Name:     "checkBindMapKeys",
+	Doc:      "check that the keys of BindMap bindings are non-empty constants and unique per type and annotation over all modules",
+	Run:      runMapKeyAnalyzer,
+	Requires: []*analysis.Analyzer{programAnalyzer},
+}
+
+// This function reports invalid keys of the bindings in this package
+// and keys bound twice which were not visible together in an imported package.
+// Like duplicate bindings, the keys of different modules replace each other only if the modules are installed by the same injector.
+func runMapKeyAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	program := pass.ResultOf[programAnalyzer].(*program)
+	installed := program.installedTogether()
+
+	var keys []string
+	groups := make(map[string][]boundType)
+	for _, bound := range program.bindings {
+		if bound.Kind != "BindMap" {
+			continue
+		}
+		if binding, ok := program.localBindings[bound.id()]; ok {
+			reportInvalidMapKey(pass, bound, binding)
+		}
+		if !bound.MapKeyKnown {
+			continue
+		}
+		key := bound.key() + " " + strconv.Quote(bound.MapKey)
+		if groups[key] == nil {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], bound)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		for j := 1; j < len(group); j++ {
+			for i := 0; i < j; i++ {
+				if program.DeclaredByImport(group[i].id(), group[j].id()) {
+					continue
+				}
+				if !sameUnit(group[i], group[j]) && !installed(group[i].Module, group[j].Module) {
+					continue
+				}
+				name := strconv.Quote(group[j].Name)
+				if group[j].Annotation != "" {
+					name += fmt.Sprintf(" annotated with %q", group[j].Annotation)
+				}
+				reportBindingPair(pass, program, group[i], group[j], flanalysis.Diagnostic{
+					Message:  fmt.Sprintf("Duplicate Map Key! %s is bound to the key %q in %s and in %s, the second Binding replaces the first one", name, group[j].MapKey, group[i], group[j]),
+					Category: "duplicateMapKey",
+				})
+				break
+			}
+		}
+	}
+	return nil, nil
+}
+
+func reportInvalidMapKey(pass *analysis.Pass, bound boundType, binding *Binding) {
+	message := ""
+	switch {
+	case !bound.MapKeyKnown:
+		message = fmt.Sprintf("Invalid Map Key! The key of %q is not a constant string, duplicate keys can't be detected", bound.Name)
+	case bound.MapKey == "":
+		message = fmt.Sprintf("Invalid Map Key! %q is bound to an empty key", bound.Name)
+	default:
+		return
+	}
+	var node ast.Node = binding.BindCall
+	if binding.MapKey != nil {
+		node = binding.MapKey
+	}
+	flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+		Node:     node,
+		Message:  message,
+		Category: "invalidMapKey",
+	})
+}
@@ -0,0 +1,179 @@
+package bind
+
+import (
+	"fmt"
+	"strings"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+// DependsAnalyzer checks that a module lists the modules it consumes bindings from in its Depends() method
+// and that the declared module dependencies don't form a cycle.
+var DependsAnalyzer = &analysis.Analyzer{
+	Name:     "checkModuleDependencies",
+	Doc:      "check that a module lists the modules binding the interfaces injected in its packages in Depends() and that the module dependencies don't form a cycle",
+	Run:      runDependsAnalyzer,
+	Requires: []*analysis.Analyzer{programAnalyzer},
+}
+
+// This function checks the modules known in this package.
+// An interface injected in the package of a module or below is consumed by the module with the longest matching package path.
+// If none of the modules binding the interface is reachable by Depends() the module misses a dependency.
+// The modules reachable by Depends() are always known where the module is known, as they need to be imported.
+func runDependsAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	program := pass.ResultOf[programAnalyzer].(*program)
+
+	modules := make(map[string]dingoModule)
+	var edges []dependency
+	for _, module := range program.modules {
+		modules[module.Type] = module
+		edges = append(edges, module.Depends...)
+	}
+	graph := newDependencyGraph(edges)
+	reportModuleCycles(pass, program, graph)
+
+	// The modules binding an interface without annotation, an empty module means the binding is not declared by a module
+	binders := make(map[string][]boundType)
+	for _, bound := range program.bindings {
+		if bound.single() && bound.Annotation == "" {
+			binders[bound.Type] = append(binders[bound.Type], bound)
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, dependency := range program.dependencies {
+		if !dependency.Injected || !dependency.Interface || dependency.Collection != "" {
+			continue
+		}
+		bindings := binders[dependency.To]
+		consumers := consumingModules(program.modules, dependency.Package)
+		if len(bindings) == 0 || len(consumers) == 0 || !allKnown(consumers) {
+			continue
+		}
+		if bound := missingDependency(graph, modules, consumers, bindings); bound != nil {
+			consumer := consumers[0]
+			if reported[consumer.Type+" "+dependency.To] {
+				continue
+			}
+			reported[consumer.Type+" "+dependency.To] = true
+			if program.DeclaredByImport(consumer.id(), dependency.id(), bound.id()) {
+				continue
+			}
+			reportMissingDependency(pass, program, consumer, dependency, *bound, modules[bound.Module])
+		}
+	}
+	return nil, nil
+}
+
+// Returns the modules with the longest package path containing the given package
+func consumingModules(modules []dingoModule, pkg string) []dingoModule {
+	var consumers []dingoModule
+	longest := -1
+	for _, module := range modules {
+		if module.Package != pkg && !strings.HasPrefix(pkg, module.Package+"/") {
+			continue
+		}
+		if len(module.Package) > longest {
+			longest = len(module.Package)
+			consumers = nil
+		}
+		if len(module.Package) == longest {
+			consumers = append(consumers, module)
+		}
+	}
+	return consumers
+}
+
+func allKnown(modules []dingoModule) bool {
+	for _, module := range modules {
+		if !module.DependsKnown {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns a binding of a module the consumers don't reach by Depends(), nil if one of the bindings is reachable.
+// Bindings outside of modules can't be judged, so there is no missing dependency then.
+// The same applies to unexported modules of other packages, which are added by the application as they can't be listed in Depends().
+func missingDependency(graph *dependencyGraph, modules map[string]dingoModule, consumers []dingoModule, bindings []boundType) *boundType {
+	reachable := make(map[string]bool)
+	for _, consumer := range consumers {
+		for module := range graph.reachable(consumer.Type) {
+			reachable[module] = true
+		}
+	}
+	for _, bound := range bindings {
+		binder, known := modules[bound.Module]
+		if !known || reachable[bound.Module] {
+			return nil
+		}
+		if !binder.Exported && binder.Package != consumers[0].Package {
+			return nil
+		}
+	}
+	return &bindings[0]
+}
+
+// Reports the module if it is declared in this package, otherwise the injection or the import bringing in the module
+func reportMissingDependency(pass *analysis.Pass, program *program, consumer dingoModule, dependency dependency, bound boundType, binder dingoModule) {
+	diagnostic := flanalysis.Diagnostic{
+		Message: fmt.Sprintf("Missing Module Dependency! %q consumes %q injected as %s (%s), which is bound by %q in %s, add it to Depends()",
+			consumer.Name, dependency.ToName, dependency.Description, dependency.Location, binder.Name, bound),
+		Category: "missingModuleDependency",
+	}
+	if decl, ok := program.localModules[consumer.id()]; ok {
+		diagnostic.Node = decl.Name
+	} else if node, ok := program.localDependencies[dependency.id()]; ok {
+		diagnostic.Node = node
+	} else {
+		diagnostic.Node = program.ImportSpec(pass, consumer.id())
+	}
+	if diagnostic.Node != nil {
+		flanalysis.ReportDiagnostic(pass, diagnostic)
+	}
+}
+
+// Reports one cycle for every group of modules depending on each other
+func reportModuleCycles(pass *analysis.Pass, program *program, graph *dependencyGraph) {
+	for _, component := range graph.components() {
+		cycle := graph.cycle(component)
+		if cycle == nil {
+			continue
+		}
+		ids := make([]string, len(cycle))
+		steps := make([]string, len(cycle))
+		for i, dependency := range cycle {
+			ids[i] = dependency.id()
+			steps[i] = fmt.Sprintf("%q depends on %q (%s)", dependency.FromName, dependency.ToName, dependency.Location)
+		}
+		if program.DeclaredByImport(ids...) {
+			continue
+		}
+		if node := cycleNode(pass, program, ids); node != nil {
+			flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+				Node:     node,
+				Message:  fmt.Sprintf("Module Cycle! %s", strings.Join(steps, ", ")),
+				Category: "moduleCycle",
+			})
+		}
+	}
+}
+
+// Returns the nodes reachable from the given node, including itself
+func (g *dependencyGraph) reachable(from string) map[string]bool {
+	reached := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range g.edges[node] {
+			if !reached[edge.To] {
+				reached[edge.To] = true
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+	return reached
+}
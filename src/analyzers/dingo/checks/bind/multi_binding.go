@@ -0,0 +1,88 @@
+package bind
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+// MultiBindingAnalyzer checks that injected slices and maps of interfaces are provided by BindMulti and BindMap bindings.
+// Dingo injects an empty slice or map if there is no such binding.
+var MultiBindingAnalyzer = &analysis.Analyzer{
+	Name:     "checkMultiBindingConsumers",
+	Doc:      "check that an injected []T is bound by BindMulti(), a map[string]T by BindMap() and that a T bound only by these is injected as slice or map",
+	Run:      runMultiBindingAnalyzer,
+	Requires: []*analysis.Analyzer{programAnalyzer},
+}
+
+// All modules are known only in the main packages of the applications, the injections are checked there like unbound interfaces.
+func runMultiBindingAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	if !facts.IsApplication(pass.Pkg) {
+		return nil, nil
+	}
+	program := pass.ResultOf[programAnalyzer].(*program)
+
+	// The kinds of bindings per type and annotation
+	kinds := make(map[string]map[string]bool)
+	for _, binding := range program.bindings {
+		if kinds[binding.key()] == nil {
+			kinds[binding.key()] = make(map[string]bool)
+		}
+		kinds[binding.key()][binding.Kind] = true
+	}
+	for _, dependency := range program.dependencies {
+		if !dependency.Injected || !dependency.Interface {
+			continue
+		}
+		bound := kinds[dependency.To+" "+strconv.Quote("")]
+		message := ""
+		switch {
+		case dependency.Collection == "[]" && !bound["BindMulti"]:
+			message = fmt.Sprintf("Missing Multi Binding! []%s is injected as %s (%s) but %q is not bound by BindMulti(), the slice is always empty", dependency.ToName, dependency.Description, dependency.Location, dependency.ToName)
+		case dependency.Collection == "map[string]" && !bound["BindMap"]:
+			message = fmt.Sprintf("Missing Map Binding! map[string]%s is injected as %s (%s) but %q is not bound by BindMap(), the map is always empty", dependency.ToName, dependency.Description, dependency.Location, dependency.ToName)
+		case dependency.Collection == "" && !bound["Bind"] && !bound["Override"] && (bound["BindMulti"] || bound["BindMap"]):
+			message = fmt.Sprintf("Missing Single Binding! %q is injected as %s (%s) but only bound by %s, inject %s instead", dependency.ToName, dependency.Description, dependency.Location, multiKinds(bound), multiTypes(bound, dependency.ToName))
+		default:
+			continue
+		}
+		node, ok := program.localDependencies[dependency.id()]
+		if !ok {
+			node = program.ImportSpec(pass, dependency.id())
+		}
+		if node == nil {
+			continue
+		}
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     node,
+			Message:  message,
+			Category: "multiBindingConsumer",
+		})
+	}
+	return nil, nil
+}
+
+func multiKinds(bound map[string]bool) string {
+	var kinds []string
+	for _, kind := range []string{"BindMulti", "BindMap"} {
+		if bound[kind] {
+			kinds = append(kinds, kind+"()")
+		}
+	}
+	return strings.Join(kinds, " and ")
+}
+
+func multiTypes(bound map[string]bool, name string) string {
+	var collections []string
+	if bound["BindMulti"] {
+		collections = append(collections, "[]"+name)
+	}
+	if bound["BindMap"] {
+		collections = append(collections, "map[string]"+name)
+	}
+	return strings.Join(collections, " or ")
+}
@@ -0,0 +1,108 @@
+package bind
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+// OverrideAnalyzer checks that an Override replaces an existing binding of another module.
+// Without a binding of the same type and annotation the Override silently is a plain binding.
+var OverrideAnalyzer = &analysis.Analyzer{
+	Name:     "checkOverrideBinding",
+	Doc:      "check that Override() replaces an existing Bind() of the same type and annotation, doesn't target a BindMulti() and binds a correct target",
+	Run:      runOverrideAnalyzer,
+	Requires: []*analysis.Analyzer{BindingsAnalyzer, programAnalyzer},
+}
+
+// The targets of the overrides are checked in every package.
+// All modules are known only in the main packages of the applications, the replaced bindings are looked up there.
+// Overrides of other packages are reported at the import bringing them in.
+func runOverrideAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	for _, binding := range checkedOnce(pass.ResultOf[BindingsAnalyzer].([]*Binding)) {
+		// Overrides which can't be verified are skipped, the binding check logs the reasons in debug mode
+		if binding.Kind == "Override" && toCalls[binding.TargetKind] {
+			checkBindingTarget(pass, binding, "Override")
+		}
+	}
+
+	if !facts.IsApplication(pass.Pkg) {
+		return nil, nil
+	}
+	program := pass.ResultOf[programAnalyzer].(*program)
+
+	bound := make(map[string]map[string]bool)
+	annotations := make(map[string]map[string]bool)
+	for _, binding := range program.bindings {
+		if binding.Kind == "Override" {
+			continue
+		}
+		if bound[binding.key()] == nil {
+			bound[binding.key()] = make(map[string]bool)
+		}
+		bound[binding.key()][binding.Kind] = true
+		if binding.Kind == "Bind" {
+			if annotations[binding.Type] == nil {
+				annotations[binding.Type] = make(map[string]bool)
+			}
+			annotations[binding.Type][binding.Annotation] = true
+		}
+	}
+
+	for _, override := range program.bindings {
+		if override.Kind != "Override" || bound[override.key()]["Bind"] {
+			continue
+		}
+		name := strconv.Quote(override.Name)
+		if override.Annotation != "" {
+			name += fmt.Sprintf(" annotated with %q", override.Annotation)
+		}
+		var message string
+		switch {
+		case bound[override.key()]["BindMulti"] || bound[override.key()]["BindMap"]:
+			message = fmt.Sprintf("Override of Multi Binding! %s is overridden in %s but only bound by %s, which Override() doesn't replace", name, override, multiKinds(bound[override.key()]))
+		case len(annotations[override.Type]) > 0:
+			message = fmt.Sprintf("Missing Original Binding! %s is overridden in %s but only bound %s", name, override, describeAnnotations(annotations[override.Type]))
+		default:
+			message = fmt.Sprintf("Missing Original Binding! %s is overridden in %s but not bound in any module, use Bind() instead", name, override)
+		}
+
+		var diagnostic flanalysis.Diagnostic
+		if binding, ok := program.localBindings[override.id()]; ok {
+			diagnostic.Node = binding.BindCall
+		} else {
+			diagnostic.Node = program.ImportSpec(pass, override.id())
+		}
+		if diagnostic.Node == nil {
+			continue
+		}
+		diagnostic.Message = message
+		diagnostic.Category = "overrideBinding"
+		flanalysis.ReportDiagnostic(pass, diagnostic)
+	}
+	return nil, nil
+}
+
+// Describes the annotations a type is bound with, e.g. `without annotation and with "a"`
+func describeAnnotations(annotations map[string]bool) string {
+	var quoted []string
+	for annotation := range annotations {
+		if annotation != "" {
+			quoted = append(quoted, strconv.Quote(annotation))
+		}
+	}
+	sort.Strings(quoted)
+	var parts []string
+	if annotations[""] {
+		parts = append(parts, "without annotation")
+	}
+	if len(quoted) > 0 {
+		parts = append(parts, "with the annotation "+strings.Join(quoted, ", "))
+	}
+	return strings.Join(parts, " and ")
+}
@@ -0,0 +1,773 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// programAnalyzer provides the modules, bindings and dependencies of a package.
+// In the main packages of the applications those of all imported packages are added, as all modules come together there.
+// The checks which need to know the bindings of all modules use its result, it doesn't report anything on its own.
+var programAnalyzer = &analysis.Analyzer{
+	Name:       "dingoProgram",
+	Doc:        "collect the dingo modules, bindings and dependencies of a package and in main packages those of all packages they import",
+	Run:        runProgramAnalyzer,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer, BindingsAnalyzer},
+	ResultType: reflect.TypeOf(new(program)),
+	FactTypes:  []analysis.Fact{new(programFact), new(expensiveFact)},
+}
+
+// programFact passes the modules, bindings and dependencies declared in a package on to the main packages.
+// Only the data of the package itself is passed, the main packages collect the facts of all packages they import.
+type programFact struct {
+	Modules      []dingoModule
+	Bindings     []boundType
+	Dependencies []dependency
+	// ChildInjectors are the calls creating a child injector
+	ChildInjectors []facts.Location
+	// Installations are the module types installed together by an injector
+	Installations [][]string
+	// Imports are the paths of the imported packages having a programFact, so the import bringing in a declaration can be found
+	Imports []string
+}
+
+// AFact marks programFact as analysis.Fact
+func (*programFact) AFact() {}
+
+// ImportPaths marks programFact as facts.Fact
+func (f *programFact) ImportPaths() []string {
+	return f.Imports
+}
+
+func (f *programFact) String() string {
+	return fmt.Sprintf("program(%d modules, %d bindings, %d dependencies, %d child injectors, %d installations, %d imports)",
+		len(f.Modules), len(f.Bindings), len(f.Dependencies), len(f.ChildInjectors), len(f.Installations), len(f.Imports))
+}
+
+// expensiveFact marks a function by an `//flamalyzer:expensive` comment
+type expensiveFact struct{}
+
+// AFact marks expensiveFact as analysis.Fact
+func (*expensiveFact) AFact() {}
+
+func (*expensiveFact) String() string {
+	return "expensive"
+}
+
+// dingoModule is a type with a Configure(*dingo.Injector) method
+type dingoModule struct {
+	// Location is the name of the Configure method
+	Location facts.Location
+	// Type is the module type, Name is its short form used in messages
+	Type     string
+	Name     string
+	Package  string
+	Exported bool
+	// Depends are the modules returned by the Depends() method, DependsKnown is set if all of them could be determined
+	Depends      []dependency
+	DependsKnown bool
+}
+
+func (m dingoModule) id() string {
+	return fmt.Sprintf("module %s:%d:%d", m.Location.Filename, m.Location.Line, m.Location.Column)
+}
+
+// boundType is the part of a binding needed by the checks in other packages
+type boundType struct {
+	// Location is the call of the injector function
+	Location facts.Location
+	// Kind is the name of the injector function, e.g. "Bind" or "BindMulti"
+	Kind string
+	// Package is the path of the package declaring the binding
+	Package string
+	// Type is the bound type, Name is its short form used in messages
+	Type       string
+	Name       string
+	Annotation string
+	// Scope is the name of the scope variable passed by In or set by AsEagerSingleton, e.g. "Singleton"
+	Scope string
+	// MapKey is the key of a BindMap binding, MapKeyKnown is set if the key is a constant
+	MapKey      string
+	MapKeyKnown bool
+	// Target describes the target if dingo treats two bindings with the same target as equal, otherwise it is empty
+	Target string
+	// TargetKind is the function binding the target, e.g. "To" or "ToProvider", TargetType is the full name of the target type
+	// or provider function, empty if it is no named type or function, TargetName is its short form
+	TargetKind string
+	TargetType string
+	TargetName string
+	Eager      bool
+	Function   string
+	// Module is the type of the module the binding is declared in, empty if it is not declared by a Configure method
+	Module string
+}
+
+// The type is part of the id, as a binding of a generic helper function binds a type per instance
+func (b boundType) id() string {
+	return fmt.Sprintf("binding %s:%d:%d %s", b.Location.Filename, b.Location.Line, b.Location.Column, b.Type)
+}
+
+func (b boundType) key() string {
+	return b.Type + " " + strconv.Quote(b.Annotation)
+}
+
+// Checks if the binding is used to inject the type itself, in contrast to BindMulti and BindMap
+func (b boundType) single() bool {
+	return b.Kind == "Bind" || b.Kind == "Override"
+}
+
+// Dingo accepts a type bound twice if both bindings are equal, which is the case for the same type or no target
+func (b boundType) equal(other boundType) bool {
+	return b.Target != "" && b.Target == other.Target
+}
+
+// Describes the location of the binding for messages
+func (b boundType) String() string {
+	return fmt.Sprintf("%s (%s)", b.Function, b.Location)
+}
+
+// dependency is an edge of the dependency graph: dingo needs an instance of To to create an instance of From
+type dependency struct {
+	// Location is the parameter, field or binding target declaring the dependency
+	Location facts.Location
+	// Package is the path of the package declaring the dependency
+	Package string
+	// From and To are the types, FromName and ToName their short forms used in messages.
+	// From is empty if the dependency belongs to an annotated binding.
+	From     string
+	FromName string
+	To       string
+	ToName   string
+	// Interface is set if To is an interface
+	Interface bool
+	// Collection is "[]" or "map[string]" if a slice or map of To is injected, which is provided by BindMulti or BindMap
+	Collection string
+	// Injected is set for injections, otherwise the dependency is the target of a binding
+	Injected bool
+	// Description tells where the dependency is declared, e.g. `parameter "x" of (*module.Service).Inject`
+	Description string
+}
+
+func (d dependency) id() string {
+	return fmt.Sprintf("dependency %s:%d:%d %s %s", d.Location.Filename, d.Location.Line, d.Location.Column, d.To, d.Description)
+}
+
+// program is the result of the programAnalyzer
+type program struct {
+	// modules, bindings and dependencies of this package, in main packages also those of the imported packages,
+	// in the order the modules are configured
+	modules      []dingoModule
+	bindings     []boundType
+	dependencies []dependency
+	// the calls creating a child injector, in main packages also those of the imported packages
+	childInjectors []facts.Location
+	// the module types installed together by an injector, in main packages also those of the imported packages
+	installations [][]string
+	// the full names of the functions marked as expensive which are declared or bound as provider in this package
+	expensive map[string]bool
+	// the bindings and the nodes of the modules and dependencies declared in this package by their id
+	localModules      map[string]*ast.FuncDecl
+	localBindings     map[string]*Binding
+	localDependencies map[string]ast.Node
+	// locates the modules, bindings and dependencies of the imported packages
+	facts.Imports
+}
+
+// Returns a function checking if two modules are installed by the same injector, directly or reached by Depends()
+func (p *program) installedTogether() func(first string, second string) bool {
+	depends := make(map[string][]string)
+	for _, module := range p.modules {
+		for _, dependency := range module.Depends {
+			depends[module.Type] = append(depends[module.Type], dependency.To)
+		}
+	}
+	var injectors []map[string]bool
+	for _, installation := range p.installations {
+		installed := make(map[string]bool)
+		var install func(module string)
+		install = func(module string) {
+			if installed[module] {
+				return
+			}
+			installed[module] = true
+			for _, dependency := range depends[module] {
+				install(dependency)
+			}
+		}
+		for _, module := range installation {
+			install(module)
+		}
+		injectors = append(injectors, installed)
+	}
+	return func(first string, second string) bool {
+		if first == "" || second == "" {
+			return false
+		}
+		for _, installed := range injectors {
+			if installed[first] && installed[second] {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Collects the bindings and dependencies of this package, main packages add those of all imported packages
+func runProgramAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	result := &program{
+		localModules:      make(map[string]*ast.FuncDecl),
+		localBindings:     make(map[string]*Binding),
+		localDependencies: make(map[string]ast.Node),
+		expensive:         make(map[string]bool),
+	}
+	own := new(programFact)
+	for _, binding := range pass.ResultOf[BindingsAnalyzer].([]*Binding) {
+		if bound, ok := newBoundType(pass, binding); ok {
+			result.localBindings[bound.id()] = binding
+			own.Bindings = append(own.Bindings, bound)
+		}
+	}
+	own.Dependencies = collectDependencies(pass, result.localDependencies)
+	own.Modules = collectModules(pass, result.localModules, result.localDependencies)
+	var expensive []*types.Func
+	own.ChildInjectors, expensive = collectScopeHints(pass)
+	own.Installations = collectInstallations(pass)
+	for _, fn := range expensive {
+		result.expensive[fn.FullName()] = true
+		pass.ExportObjectFact(fn, new(expensiveFact))
+	}
+	addExpensiveProviders(pass, result.expensive)
+
+	own.Imports = facts.ImportPaths(pass, new(programFact))
+	if facts.IsApplication(pass.Pkg) {
+		result.addImported(pass, own.Imports)
+	}
+	result.modules = append(result.modules, own.Modules...)
+	result.bindings = append(result.bindings, own.Bindings...)
+	result.dependencies = append(result.dependencies, own.Dependencies...)
+	result.childInjectors = append(result.childInjectors, own.ChildInjectors...)
+	result.installations = append(result.installations, own.Installations...)
+
+	if len(own.Modules) > 0 || len(own.Bindings) > 0 || len(own.Dependencies) > 0 || len(own.ChildInjectors) > 0 ||
+		len(own.Installations) > 0 || len(own.Imports) > 0 {
+		pass.ExportPackageFact(own)
+	}
+	return result, nil
+}
+
+// Adds the modules, bindings and dependencies of all packages reached by the given imports.
+// The imported packages are configured before the packages importing them.
+func (p *program) addImported(pass *analysis.Pass, imports []string) {
+	p.Imports = facts.Merge(pass, imports, func(fact *programFact) []string {
+		var ids []string
+		for _, module := range fact.Modules {
+			ids = append(ids, module.id())
+			for _, dependency := range module.Depends {
+				ids = append(ids, dependency.id())
+			}
+			p.modules = append(p.modules, module)
+		}
+		for _, bound := range fact.Bindings {
+			ids = append(ids, bound.id())
+			p.bindings = append(p.bindings, bound)
+		}
+		for _, dependency := range fact.Dependencies {
+			ids = append(ids, dependency.id())
+			p.dependencies = append(p.dependencies, dependency)
+		}
+		p.childInjectors = append(p.childInjectors, fact.ChildInjectors...)
+		p.installations = append(p.installations, fact.Installations...)
+		return ids
+	})
+}
+
+// Adds the providers bound in this package which are marked as expensive in the package declaring them
+func addExpensiveProviders(pass *analysis.Pass, expensive map[string]bool) {
+	for _, binding := range pass.ResultOf[BindingsAnalyzer].([]*Binding) {
+		if binding.TargetKind != "ToProvider" {
+			continue
+		}
+		fn, ok := pass.TypesInfo.Uses[calleeIdent(binding.Target)].(*types.Func)
+		if ok && fn.Pkg() != pass.Pkg && pass.ImportObjectFact(fn, new(expensiveFact)) {
+			expensive[fn.FullName()] = true
+		}
+	}
+}
+
+// Creates the boundType of a binding.
+// Bindings with an annotation which is not a constant can't be compared and are left out.
+func newBoundType(pass *analysis.Pass, binding *Binding) (boundType, bool) {
+	// The types of a generic helper function are known only for its instances
+	if binding.Type == nil || mentionsTypeParam(binding.Type) {
+		return boundType{}, false
+	}
+	if binding.AnnotationExpr != nil && pass.TypesInfo.Types[binding.AnnotationExpr].Value == nil {
+		return boundType{}, false
+	}
+
+	// Dingo binds the type the pointer points to
+	bound := binding.Type
+	if pointer, ok := bound.(*types.Pointer); ok {
+		bound = pointer.Elem()
+	}
+	result := boundType{
+		Location:   facts.NewLocation(pass.Fset, binding.BindCall.Pos()),
+		Kind:       binding.Kind,
+		Package:    pass.Pkg.Path(),
+		Type:       types.TypeString(bound, nil),
+		Name:       types.TypeString(bound, facts.PackageName),
+		Annotation: binding.Annotation,
+		Scope:      binding.Scope,
+		Function:   facts.FunctionName(binding.Function),
+	}
+	if binding.MapKey != nil {
+		if value := pass.TypesInfo.Types[binding.MapKey].Value; value != nil && value.Kind() == constant.String {
+			result.MapKey = constant.StringVal(value)
+			result.MapKeyKnown = true
+		}
+	}
+	if fn := binding.Function; fn != nil && fn.Name() == "Configure" && fn.Type().(*types.Signature).Recv() != nil {
+		if module := dependencyType(fn.Type().(*types.Signature).Recv().Type()); module != nil {
+			result.Module = types.TypeString(module, nil)
+		}
+	}
+	result.TargetKind, result.Eager = binding.TargetKind, binding.Eager
+	result.TargetType, result.TargetName = targetName(pass, binding)
+	switch binding.TargetKind {
+	case "":
+		result.Target = fmt.Sprintf("none %s %t", binding.Scope, binding.Eager)
+	case "To":
+		if target := binding.TargetType; target != nil {
+			result.Target = fmt.Sprintf("%s %s %t", types.TypeString(target, nil), binding.Scope, binding.Eager)
+		}
+	}
+	return result, true
+}
+
+// Returns the full and the short name of the target of a binding: the type created for To and ToInstance, the function for ToProvider.
+// The full name is empty if the target is neither a named type nor a declared function, e.g. a function literal.
+func targetName(pass *analysis.Pass, binding *Binding) (string, string) {
+	if binding.Target == nil {
+		return "", ""
+	}
+	if binding.TargetKind == "ToProvider" {
+		// Instances of generic providers are named by the generic function, e.g. `newRepository[Product]`
+		target := astutil.Unparen(binding.Target)
+		switch index := target.(type) {
+		case *ast.IndexExpr:
+			target = index.X
+		case *ast.IndexListExpr:
+			target = index.X
+		}
+		if fn, ok := pass.TypesInfo.Uses[calleeIdent(target)].(*types.Func); ok {
+			return fn.FullName(), facts.FunctionName(fn)
+		}
+	}
+	typ := binding.TargetType
+	if typ == nil {
+		return "", types.ExprString(binding.Target)
+	}
+	if named := dependencyType(typ); named != nil && binding.TargetKind != "ToProvider" {
+		return types.TypeString(named, nil), types.TypeString(named, facts.PackageName)
+	}
+	return "", types.TypeString(typ, facts.PackageName)
+}
+
+// Collects the dependencies declared by
+// - the parameters of Inject methods
+// - the parameters of providers bound by ToProvider
+// - struct fields tagged with `inject:""`
+// - the targets of bindings without annotation
+//
+// Dependencies on types which are no named types, e.g. a Provider `func() T`, are left out.
+// The dependencies of generic types are known only for their instances, they are added where an instance is used.
+func collectDependencies(pass *analysis.Pass, nodes map[string]ast.Node) []dependency {
+	var dependencies []dependency
+	instances := make(map[string]bool)
+	var addInstance func(node ast.Node, typ types.Type)
+	add := func(node ast.Node, from types.Type, to types.Type, injected bool, description string) {
+		collection := ""
+		if to != nil {
+			switch underlying := to.Underlying().(type) {
+			case *types.Slice:
+				collection, to = "[]", providedType(underlying.Elem())
+			case *types.Map:
+				if key, ok := underlying.Key().Underlying().(*types.Basic); ok && key.Kind() == types.String {
+					collection, to = "map[string]", providedType(underlying.Elem())
+				}
+			}
+		}
+		// Only an interface itself needs a binding, not a pointer to it
+		isInterface := false
+		if named, ok := to.(*types.Named); ok {
+			_, isInterface = named.Underlying().(*types.Interface)
+		}
+		if to = dependencyType(to); to == nil || mentionsTypeParam(to) || (from != nil && mentionsTypeParam(from)) {
+			return
+		}
+		dependency := dependency{
+			Location:    facts.NewLocation(pass.Fset, node.Pos()),
+			Package:     pass.Pkg.Path(),
+			To:          types.TypeString(to, nil),
+			ToName:      types.TypeString(to, facts.PackageName),
+			Interface:   isInterface,
+			Collection:  collection,
+			Injected:    injected,
+			Description: description,
+		}
+		if from = dependencyType(from); from != nil {
+			dependency.From = types.TypeString(from, nil)
+			dependency.FromName = types.TypeString(from, facts.PackageName)
+		}
+		nodes[dependency.id()] = node
+		dependencies = append(dependencies, dependency)
+		addInstance(node, to)
+	}
+	addInstance = func(node ast.Node, typ types.Type) {
+		named, ok := dependencyType(typ).(*types.Named)
+		if !ok || named.TypeArgs().Len() == 0 || mentionsTypeParam(named) || instances[types.TypeString(named, nil)] {
+			return
+		}
+		instances[types.TypeString(named, nil)] = true
+		addInstanceDependencies(named, node, add)
+	}
+
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.TypeSpec)(nil),
+	}
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		switch decl := n.(type) {
+		case *ast.FuncDecl:
+			fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok || decl.Recv == nil || decl.Name.Name != "Inject" {
+				return
+			}
+			receiver := fn.Type().(*types.Signature).Recv().Type()
+			for _, field := range decl.Type.Params.List {
+				for _, name := range field.Names {
+					add(name, receiver, pass.TypesInfo.TypeOf(field.Type), true, fmt.Sprintf("parameter %q of %s", name.Name, facts.FunctionName(fn)))
+				}
+			}
+		case *ast.TypeSpec:
+			structType, ok := decl.Type.(*ast.StructType)
+			obj := pass.TypesInfo.Defs[decl.Name]
+			if !ok || obj == nil {
+				return
+			}
+			for _, field := range structType.Fields.List {
+				if field.Tag == nil {
+					continue
+				}
+				tag, err := strconv.Unquote(field.Tag.Value)
+				if err != nil {
+					continue
+				}
+				if annotation, ok := reflect.StructTag(tag).Lookup("inject"); !ok || annotation != "" {
+					continue
+				}
+				for _, name := range field.Names {
+					add(name, obj.Type(), pass.TypesInfo.TypeOf(field.Type), true, fmt.Sprintf("field %q of %q", name.Name, pass.Pkg.Name()+"."+decl.Name.Name))
+				}
+			}
+		}
+	})
+
+	for _, binding := range pass.ResultOf[BindingsAnalyzer].([]*Binding) {
+		if bindCalls[binding.Kind] || binding.Kind == "Override" {
+			addBindingDependencies(pass, binding, add)
+			if binding.TargetKind == "To" {
+				addInstance(binding.Target, binding.TargetType)
+			}
+		}
+	}
+	return dependencies
+}
+
+// Adds the dependencies of an instance of a generic type, the parameters of its Inject method and its fields tagged with `inject:""`.
+// The type parameters of the generic type are replaced by the type arguments of the instance.
+func addInstanceDependencies(instance *types.Named, node ast.Node, add func(node ast.Node, from types.Type, to types.Type, injected bool, description string)) {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(instance), false, instance.Obj().Pkg(), "Inject")
+	if inject, ok := obj.(*types.Func); ok {
+		params := inject.Type().(*types.Signature).Params()
+		for i := 0; i < params.Len(); i++ {
+			add(node, instance, params.At(i).Type(), true, fmt.Sprintf("parameter %q of %s", params.At(i).Name(), facts.FunctionName(inject)))
+		}
+	}
+	structType, ok := instance.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	for i := 0; i < structType.NumFields(); i++ {
+		if annotation, ok := reflect.StructTag(structType.Tag(i)).Lookup("inject"); !ok || annotation != "" {
+			continue
+		}
+		field := structType.Field(i)
+		add(node, instance, field.Type(), true, fmt.Sprintf("field %q of %q", field.Name(), types.TypeString(instance, facts.PackageName)))
+	}
+}
+
+// Adds the dependencies of a binding: the target or the parameters of the provider.
+// Annotated bindings are not injected by the type alone, so the bound type is not passed as origin.
+func addBindingDependencies(pass *analysis.Pass, binding *Binding, add func(node ast.Node, from types.Type, to types.Type, injected bool, description string)) {
+	from := binding.Type
+	if binding.AnnotationExpr != nil {
+		value := pass.TypesInfo.Types[binding.AnnotationExpr].Value
+		if value == nil || value.Kind() != constant.String || constant.StringVal(value) != "" {
+			from = nil
+		}
+	}
+	if binding.Kind == "BindMulti" || binding.Kind == "BindMap" {
+		from = nil
+	}
+
+	switch binding.TargetKind {
+	case "To":
+		if from != nil {
+			add(binding.Target, from, binding.TargetType, false, fmt.Sprintf("the Binding in %s", facts.FunctionName(binding.Function)))
+		}
+	case "ToProvider":
+		signature, ok := binding.TargetType.(*types.Signature)
+		if !ok {
+			return
+		}
+		for i := 0; i < signature.Params().Len(); i++ {
+			param := signature.Params().At(i)
+			add(binding.Target, from, param.Type(), true, fmt.Sprintf("parameter %q of the Provider bound in %s", param.Name(), facts.FunctionName(binding.Function)))
+		}
+	}
+}
+
+// Collects the types with a Configure(*dingo.Injector) method together with the modules returned by their Depends() method
+func collectModules(pass *analysis.Pass, nodes map[string]*ast.FuncDecl, dependsNodes map[string]ast.Node) []dingoModule {
+	var modules []dingoModule
+	depends := make(map[types.Type]*ast.FuncDecl)
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+	var configures []*ast.FuncDecl
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok || decl.Recv == nil {
+			return
+		}
+		signature := fn.Type().(*types.Signature)
+		switch {
+		case fn.Name() == "Configure" && signature.Params().Len() == 1 && isInjector(signature.Params().At(0).Type()):
+			configures = append(configures, decl)
+		case fn.Name() == "Depends" && decl.Body != nil:
+			depends[dependencyType(signature.Recv().Type())] = decl
+		}
+	})
+
+	for _, decl := range configures {
+		fn := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		typ := dependencyType(fn.Type().(*types.Signature).Recv().Type())
+		if typ == nil {
+			continue
+		}
+		module := dingoModule{
+			Location:     facts.NewLocation(pass.Fset, decl.Name.Pos()),
+			Type:         types.TypeString(typ, nil),
+			Name:         types.TypeString(typ, facts.PackageName),
+			Package:      pass.Pkg.Path(),
+			Exported:     typ.(*types.Named).Obj().Exported(),
+			DependsKnown: true,
+		}
+		nodes[module.id()] = decl
+		if dependsDecl, ok := depends[typ]; ok {
+			nodes[module.id()] = dependsDecl
+			module.Depends, module.DependsKnown = dependsOf(pass, dependsDecl, typ, dependsNodes)
+		}
+		modules = append(modules, module)
+	}
+	return modules
+}
+
+// Returns the modules returned by a Depends() method, the result is only known for composite literals like
+// `return []dingo.Module{new(other.Module)}`
+func dependsOf(pass *analysis.Pass, decl *ast.FuncDecl, module types.Type, nodes map[string]ast.Node) ([]dependency, bool) {
+	var depends []dependency
+	known := true
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) != 1 {
+				known = false
+				return false
+			}
+			if ident, ok := node.Results[0].(*ast.Ident); ok && ident.Name == "nil" {
+				return false
+			}
+			literal, ok := node.Results[0].(*ast.CompositeLit)
+			if !ok {
+				known = false
+				return false
+			}
+			for _, element := range literal.Elts {
+				typ := dependencyType(pass.TypesInfo.TypeOf(element))
+				if typ == nil {
+					known = false
+					continue
+				}
+				dependency := dependency{
+					Location:    facts.NewLocation(pass.Fset, element.Pos()),
+					Package:     pass.Pkg.Path(),
+					From:        types.TypeString(module, nil),
+					FromName:    types.TypeString(module, facts.PackageName),
+					To:          types.TypeString(typ, nil),
+					ToName:      types.TypeString(typ, facts.PackageName),
+					Description: fmt.Sprintf("the Depends() method of %q", types.TypeString(module, facts.PackageName)),
+				}
+				nodes[dependency.id()] = element
+				depends = append(depends, dependency)
+			}
+			return false
+		}
+		return true
+	})
+	return depends, known
+}
+
+// Checks if the type is *dingo.Injector
+func isInjector(typ types.Type) bool {
+	pointer, ok := typ.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := pointer.Elem().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == dingoPkgPath && named.Obj().Name() == "Injector"
+}
+
+// The comment marking a provider whose call has expensive side effects, e.g. opening connections
+const expensiveDirective = "//flamalyzer:expensive"
+
+// Collects the calls of (*dingo.Injector).Child and the functions marked as expensive
+func collectScopeHints(pass *analysis.Pass) ([]facts.Location, []*types.Func) {
+	var childInjectors []facts.Location
+	var expensive []*types.Func
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if node.Doc == nil {
+				return
+			}
+			for _, comment := range node.Doc.List {
+				if strings.TrimSpace(comment.Text) != expensiveDirective {
+					continue
+				}
+				if fn, ok := pass.TypesInfo.Defs[node.Name].(*types.Func); ok {
+					expensive = append(expensive, fn)
+				}
+			}
+		case *ast.CallExpr:
+			if fn := dingoFunc(pass, node); fn != nil && isInjectorFunc(fn) && fn.Name() == "Child" {
+				childInjectors = append(childInjectors, facts.NewLocation(pass.Fset, node.Pos()))
+			}
+		}
+	})
+	return childInjectors, expensive
+}
+
+// Collects the modules installed together by an injector, i.e. the modules passed to dingo.NewInjector or InitModules
+// and the lists of modules like `[]dingo.Module{new(a.Module), new(b.Module)}` outside of Depends() methods
+func collectInstallations(pass *analysis.Pass) [][]string {
+	var installations [][]string
+	modules := func(elements []ast.Expr) []string {
+		var installed []string
+		for _, element := range elements {
+			if typ := dependencyType(pass.TypesInfo.TypeOf(element)); typ != nil && !isModule(typ) {
+				installed = append(installed, types.TypeString(typ, nil))
+			}
+		}
+		return installed
+	}
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.CompositeLit)(nil),
+	}
+	input.Nodes(nodeFilter, func(n ast.Node, push bool) bool {
+		if !push {
+			return true
+		}
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			// The modules returned by Depends() are installed by the modules depending on them
+			return node.Name.Name != "Depends" || node.Recv == nil
+		case *ast.CallExpr:
+			fn, ok := typeutil.Callee(pass.TypesInfo, node).(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != dingoPkgPath || node.Ellipsis.IsValid() {
+				return true
+			}
+			if fn.Name() == "NewInjector" || fn.Name() == "InitModules" {
+				installations = append(installations, modules(node.Args))
+			}
+		case *ast.CompositeLit:
+			slice, ok := pass.TypesInfo.TypeOf(node).(*types.Slice)
+			if ok && isModule(slice.Elem()) {
+				installations = append(installations, modules(node.Elts))
+			}
+		}
+		return true
+	})
+	return installations
+}
+
+// Checks if the type is dingo.Module
+func isModule(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == dingoPkgPath && named.Obj().Name() == "Module"
+}
+
+// Returns the type provided by a named Provider function like `type IProvider func() I`,
+// dingo injects slices and maps of such Providers for multi bindings
+func providedType(typ types.Type) types.Type {
+	named, ok := typ.(*types.Named)
+	if !ok || !strings.HasSuffix(named.Obj().Name(), "Provider") {
+		return typ
+	}
+	if signature, ok := named.Underlying().(*types.Signature); ok && signature.Results().Len() > 0 {
+		return signature.Results().At(0).Type()
+	}
+	return typ
+}
+
+// Returns the named type dingo creates for a dependency, pointers are resolved by dingo.
+// Other types like functions, slices and basic types don't lead to further dependencies.
+func dependencyType(typ types.Type) types.Type {
+	if typ == nil {
+		return nil
+	}
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	return named
+}
@@ -0,0 +1,106 @@
+package bind
+
+import (
+	"fmt"
+	"go/types"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+// ProviderAnalyzer checks if the provider of a dingo binding provides the bound type and can be called by dingo
+var ProviderAnalyzer = &analysis.Analyzer{
+	Name:     "checkCorrectProviderBinding",
+	Doc:      "check if the Provider of a Binding with the ToProvider() -Function returns the bound type and has injectable parameters",
+	Run:      runProviderAnalyzer,
+	Requires: []*analysis.Analyzer{BindingsAnalyzer},
+}
+
+// This function checks the providers passed to dingo.
+// example: injector.Bind(someInterface).ToProvider(func(dependency *Dependency) someInterface {...})
+func runProviderAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	bindings := pass.ResultOf[BindingsAnalyzer].([]*Binding)
+	for _, binding := range checkedOnce(bindings) {
+		if bindCalls[binding.Kind] && binding.TargetKind == "ToProvider" {
+			checkProvider(pass, binding)
+		}
+	}
+	return nil, nil
+}
+
+// Checks the provider of a binding
+func checkProvider(pass *analysis.Pass, binding *Binding) {
+	bindType, ok := pass.TypesInfo.Types[binding.What].Type.(*types.Pointer)
+	if !ok {
+		return
+	}
+	bound := bindType.Elem()
+	provider := binding.Target
+	providerType := pass.TypesInfo.TypeOf(provider)
+	// A provider passed as interface is only known at runtime
+	if providerType == nil || types.IsInterface(providerType) {
+		return
+	}
+
+	signature, ok := providerType.Underlying().(*types.Signature)
+	if !ok {
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     provider,
+			Message:  fmt.Sprintf("Incorrect Binding! Provider %q must be a function", providerType.String()),
+			Category: "incorrectProvider",
+		})
+		return
+	}
+
+	// Dingo uses the first result, a second one is allowed to be an error
+	results := signature.Results()
+	errorType := types.Universe.Lookup("error").Type()
+	if results.Len() == 0 || results.Len() > 2 || (results.Len() == 2 && !types.Identical(results.At(1).Type(), errorType)) {
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     provider,
+			Message:  fmt.Sprintf("Incorrect Binding! Provider %q must return a single value, optionally followed by an error", signature.String()),
+			Category: "incorrectProvider",
+		})
+	} else if provided := results.At(0).Type(); !types.AssignableTo(provided, bound) && !types.AssignableTo(provided, bindType) {
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     provider,
+			Message:  fmt.Sprintf("Incorrect Binding! Provider returns %q which is not assignable to %q", provided.String(), bound.String()),
+			Category: "incorrectProvider",
+		})
+	}
+
+	// The parameters get injected by dingo
+	params := signature.Params()
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		reason := notInjectable(param.Type())
+		if signature.Variadic() && i == params.Len()-1 {
+			reason = "variadic parameters can not be injected"
+		}
+		if reason != "" {
+			flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+				Node:     provider,
+				Message:  fmt.Sprintf("Incorrect Binding! Provider parameter %q of type %q is not injectable: %s", param.Name(), param.Type().String(), reason),
+				Category: "incorrectProvider",
+				Related:  []analysis.RelatedInformation{flanalysis.RelatedObject(param, "parameter declared here")},
+			})
+		}
+	}
+}
+
+// Returns the reason why dingo can't resolve the given type, empty if it is injectable.
+// Other types, e.g. named basic types or channels, are injected like any other type once they are bound.
+func notInjectable(typ types.Type) string {
+	switch underlying := typ.Underlying().(type) {
+	case *types.Map:
+		if basic, ok := underlying.Key().Underlying().(*types.Basic); !ok || basic.Kind() != types.String {
+			return "maps must have string keys to be injected as map binding"
+		}
+	case *types.Signature:
+		// Named functions can be bound, unnamed functions can't be injected at all
+		if _, ok := typ.(*types.Named); !ok {
+			return "functions must be named types with a `Provider` suffix to be injected as provider"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,177 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+type scopeAnalyzer struct {
+	Analyzer           *analysis.Analyzer
+	RequestScopedTypes []string
+}
+
+// NewScopeAnalyzer creates a new Analyzer which checks that the singleton scopes of dingo are used properly.
+// Types holding state of a single request are configured by their full name.
+// configuration example:
+// requestScopedTypes: ["flamingo.me/flamingo/v3/framework/web.Request", "net/http.Request"]
+func NewScopeAnalyzer(requestScopedTypes []string) *scopeAnalyzer {
+	analyzer := new(scopeAnalyzer)
+	analyzer.RequestScopedTypes = requestScopedTypes
+	analyzer.Analyzer = &analysis.Analyzer{
+		Name:     "checkScopeMisuse",
+		Doc:      "check that singletons don't hold request-scoped state, that ChildSingleton is used with child injectors only and that providers marked as expensive are not bound as eager singletons",
+		Run:      analyzer.run,
+		Requires: []*analysis.Analyzer{BindingsAnalyzer, programAnalyzer},
+	}
+	return analyzer
+}
+
+// The bindings of this package are checked for singletons holding request-scoped state and eager singletons with expensive providers.
+// Whether a child injector is created is known only in the main packages, ChildSingleton bindings are checked there.
+func (a *scopeAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
+	program := pass.ResultOf[programAnalyzer].(*program)
+
+	for _, binding := range checkedOnce(pass.ResultOf[BindingsAnalyzer].([]*Binding)) {
+		if binding.Scope != "Singleton" && binding.Scope != "ChildSingleton" {
+			continue
+		}
+		a.checkRequestScopedState(pass, binding)
+		if binding.Eager && binding.TargetKind == "ToProvider" {
+			checkExpensiveProvider(pass, program, binding)
+		}
+	}
+
+	if !facts.IsApplication(pass.Pkg) || len(program.childInjectors) > 0 {
+		return nil, nil
+	}
+	for _, bound := range program.bindings {
+		if bound.Scope != "ChildSingleton" {
+			continue
+		}
+		var node ast.Node
+		if binding, ok := program.localBindings[bound.id()]; ok {
+			node = scopeNode(binding)
+		} else {
+			node = program.ImportSpec(pass, bound.id())
+		}
+		if node == nil {
+			continue
+		}
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     node,
+			Message:  fmt.Sprintf("Scope Misuse! %q is bound in ChildSingleton scope in %s but no child injector is created, use dingo.Singleton", bound.Name, bound),
+			Category: "childSingletonWithoutChild",
+		})
+	}
+	return nil, nil
+}
+
+// Reports a singleton which gets a request-scoped type injected, the first request would be kept for all following ones
+func (a *scopeAnalyzer) checkRequestScopedState(pass *analysis.Pass, binding *Binding) {
+	var created types.Type
+	var holders []requestScopedHolder
+	switch binding.TargetKind {
+	case "":
+		created = binding.Type
+	case "To":
+		created = binding.TargetType
+	case "ToProvider":
+		signature, ok := binding.TargetType.(*types.Signature)
+		if !ok {
+			return
+		}
+		for i := 0; i < signature.Params().Len(); i++ {
+			param := signature.Params().At(i)
+			holders = append(holders, requestScopedHolder{param.Type(), fmt.Sprintf("parameter %q of the Provider", param.Name())})
+		}
+	}
+	if created != nil {
+		holders = append(holders, stateOf(created)...)
+	}
+
+	for _, holder := range holders {
+		typ := holder.typ
+		if pointer, ok := typ.(*types.Pointer); ok {
+			typ = pointer.Elem()
+		}
+		if !a.requestScoped(types.TypeString(typ, nil)) {
+			continue
+		}
+		bound := binding.Type
+		if pointer, ok := bound.(*types.Pointer); ok {
+			bound = pointer.Elem()
+		}
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node: scopeNode(binding),
+			Message: fmt.Sprintf("Scope Misuse! %q is bound in %s scope but holds the request-scoped %q by %s, don't bind it as singleton",
+				types.TypeString(bound, facts.PackageName), binding.Scope, types.TypeString(typ, facts.PackageName), holder.description),
+			Category: "requestScopedSingleton",
+		})
+		return
+	}
+}
+
+// requestScopedHolder is a field or parameter which might hold request-scoped state
+type requestScopedHolder struct {
+	typ         types.Type
+	description string
+}
+
+// Returns the fields and the parameters of the Inject method of the created type
+func stateOf(created types.Type) []requestScopedHolder {
+	var holders []requestScopedHolder
+	named := dependencyType(created)
+	if named == nil {
+		return nil
+	}
+	if structType, ok := named.Underlying().(*types.Struct); ok {
+		for i := 0; i < structType.NumFields(); i++ {
+			field := structType.Field(i)
+			holders = append(holders, requestScopedHolder{field.Type(), fmt.Sprintf("field %q of %q", field.Name(), types.TypeString(named, facts.PackageName))})
+		}
+	}
+	if inject := types.NewMethodSet(types.NewPointer(named)).Lookup(nil, "Inject"); inject != nil {
+		fn := inject.Obj().(*types.Func)
+		params := fn.Type().(*types.Signature).Params()
+		for i := 0; i < params.Len(); i++ {
+			holders = append(holders, requestScopedHolder{params.At(i).Type(), fmt.Sprintf("parameter %q of %s", params.At(i).Name(), facts.FunctionName(fn))})
+		}
+	}
+	return holders
+}
+
+func (a *scopeAnalyzer) requestScoped(typ string) bool {
+	for _, requestScoped := range a.RequestScopedTypes {
+		if requestScoped == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports an eager singleton bound to a provider marked as expensive, the provider is called when the injector is created
+func checkExpensiveProvider(pass *analysis.Pass, program *program, binding *Binding) {
+	fn, ok := pass.TypesInfo.Uses[calleeIdent(binding.Target)].(*types.Func)
+	if !ok || !program.expensive[fn.FullName()] {
+		return
+	}
+	flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+		Node:     binding.BindCall,
+		Message:  fmt.Sprintf("Scope Misuse! The Provider %s is marked as expensive but bound with AsEagerSingleton() in %s, it is called when the injector is created", facts.FunctionName(fn), facts.FunctionName(binding.Function)),
+		Category: "expensiveEagerSingleton",
+		Related:  []analysis.RelatedInformation{flanalysis.RelatedObject(fn, fmt.Sprintf("%q is marked as expensive here", fn.Name()))},
+	})
+}
+
+// Returns the scope passed by In, otherwise the call of the injector function
+func scopeNode(binding *Binding) ast.Node {
+	if binding.ScopeExpr != nil {
+		return binding.ScopeExpr
+	}
+	return binding.BindCall
+}
@@ -0,0 +1,269 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// The functions of the dingo.Injector which create or fill instances on request
+var locatorFunctions = map[string]bool{"GetInstance": true, "GetAnnotatedInstance": true, "RequestInjection": true}
+
+type serviceLocatorAnalyzer struct {
+	Analyzer     *analysis.Analyzer
+	AllowedPaths []string
+}
+
+// NewServiceLocatorAnalyzer creates a new Analyzer which checks that the injector is used as service locator only while bootstrapping,
+// i.e. in main packages, Configure methods, functions creating the injector, test files and paths matching one of the allowed glob patterns.
+// A pattern matches a consecutive part of the package path or the file path.
+// configuration example:
+// serviceLocatorAllowedPaths: ["*/bootstrap", "cmd/*"]
+func NewServiceLocatorAnalyzer(allowedPaths []string) *serviceLocatorAnalyzer {
+	analyzer := new(serviceLocatorAnalyzer)
+	analyzer.AllowedPaths = allowedPaths
+	analyzer.Analyzer = &analysis.Analyzer{
+		Name:     "checkServiceLocator",
+		Doc:      "check that GetInstance, GetAnnotatedInstance and RequestInjection are only called while bootstrapping and that type assertions on the requested instances can succeed",
+		Run:      analyzer.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+	return analyzer
+}
+
+// The calls are reported outside of the allowed locations, impossible type assertions on the requested instances everywhere.
+func (a *serviceLocatorAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	allowedPackage := pass.Pkg.Name() == "main" || a.allowedPath(pass.Pkg.Path())
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+	input.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		fn := dingoFunc(pass, call)
+		if fn == nil || !isInjectorFunc(fn) || !locatorFunctions[fn.Name()] {
+			return true
+		}
+		filename := pass.Fset.File(call.Pos()).Name()
+		if allowedPackage || strings.HasSuffix(filename, "_test.go") || a.allowedPath(filepath.ToSlash(filename)) || bootstrapping(pass, stack) {
+			return true
+		}
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     call,
+			Message:  fmt.Sprintf("Service Locator! %s must only be called while bootstrapping, inject the dependency instead", facts.FunctionName(fn)),
+			Category: "serviceLocator",
+		})
+		return true
+	})
+
+	checkTypeAssertions(pass, input)
+	return nil, nil
+}
+
+// Checks if a pattern matches a consecutive part of the slash separated path, e.g. "cmd/*" matches "example.com/app/cmd/server/main.go"
+func (a *serviceLocatorAnalyzer) allowedPath(slashPath string) bool {
+	segments := strings.Split(slashPath, "/")
+	for _, pattern := range a.AllowedPaths {
+		for start := range segments {
+			for end := start + 1; end <= len(segments); end++ {
+				if matched, _ := path.Match(pattern, strings.Join(segments[start:end], "/")); matched {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Checks if the call is part of a Configure method or of a function creating the injector by dingo.NewInjector,
+// including the function literals declared in them
+func bootstrapping(pass *analysis.Pass, stack []ast.Node) bool {
+	for _, node := range stack {
+		funcdecl, ok := node.(*ast.FuncDecl)
+		if !ok || funcdecl.Body == nil {
+			continue
+		}
+		if funcdecl.Recv != nil && funcdecl.Name.Name == "Configure" {
+			return true
+		}
+		creates := false
+		ast.Inspect(funcdecl.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok && fn.Pkg() != nil && fn.Pkg().Path() == dingoPkgPath && fn.Name() == "NewInjector" {
+					creates = true
+				}
+			}
+			return !creates
+		})
+		return creates
+	}
+	return false
+}
+
+// Reports type assertions on instances requested by GetInstance or GetAnnotatedInstance which can never succeed.
+// Only variables assigned nothing but the requested instance are followed.
+func checkTypeAssertions(pass *analysis.Pass, input *inspector.Inspector) {
+	requested := make(map[types.Object]types.Type)
+	reassigned := make(map[types.Object]bool)
+	assign := func(lhs []ast.Expr, rhs []ast.Expr) {
+		var typ types.Type
+		if len(lhs) == 2 && len(rhs) == 1 {
+			typ = requestedType(pass, rhs[0])
+		}
+		for i, expr := range lhs {
+			ident, ok := expr.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			obj := pass.TypesInfo.ObjectOf(ident)
+			if obj == nil {
+				continue
+			}
+			if i == 0 && typ != nil {
+				if _, ok := requested[obj]; ok {
+					reassigned[obj] = true
+				}
+				requested[obj] = typ
+			} else {
+				reassigned[obj] = true
+			}
+		}
+	}
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			assign(node.Lhs, node.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(node.Names))
+			for i, name := range node.Names {
+				lhs[i] = name
+			}
+			if len(node.Values) > 0 {
+				assign(lhs, node.Values)
+			}
+		}
+	})
+
+	instanceType := func(expr ast.Expr) types.Type {
+		ident, ok := astutil.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		obj := pass.TypesInfo.Uses[ident]
+		if reassigned[obj] {
+			return nil
+		}
+		return requested[obj]
+	}
+	nodeFilter = []ast.Node{
+		(*ast.TypeAssertExpr)(nil),
+		(*ast.TypeSwitchStmt)(nil),
+	}
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		switch node := n.(type) {
+		case *ast.TypeAssertExpr:
+			// The type of a type switch guard is nil, its cases are checked with the switch
+			if node.Type == nil {
+				return
+			}
+			if typ := instanceType(node.X); typ != nil {
+				checkTypeAssertion(pass, typ, node.Type)
+			}
+		case *ast.TypeSwitchStmt:
+			var guard *ast.TypeAssertExpr
+			switch assign := node.Assign.(type) {
+			case *ast.AssignStmt:
+				guard, _ = assign.Rhs[0].(*ast.TypeAssertExpr)
+			case *ast.ExprStmt:
+				guard, _ = assign.X.(*ast.TypeAssertExpr)
+			}
+			if guard == nil {
+				return
+			}
+			typ := instanceType(guard.X)
+			if typ == nil {
+				return
+			}
+			for _, clause := range node.Body.List {
+				for _, expr := range clause.(*ast.CaseClause).List {
+					checkTypeAssertion(pass, typ, expr)
+				}
+			}
+		}
+	})
+}
+
+// Returns the type requested by a call of GetInstance or GetAnnotatedInstance without the pointers, like Dingo does.
+// Requests by a reflect.Type or any other interface are unknown.
+func requestedType(pass *analysis.Pass, expr ast.Expr) types.Type {
+	call, ok := astutil.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+	fn := dingoFunc(pass, call)
+	if fn == nil || !isInjectorFunc(fn) || (fn.Name() != "GetInstance" && fn.Name() != "GetAnnotatedInstance") {
+		return nil
+	}
+	typ := pass.TypesInfo.TypeOf(call.Args[0])
+	if typ == nil || types.IsInterface(typ) {
+		return nil
+	}
+	for {
+		pointer, ok := typ.(*types.Pointer)
+		if !ok {
+			return typ
+		}
+		typ = pointer.Elem()
+	}
+}
+
+// Reports the type assertion if the instance of the requested type can never be of the asserted type.
+// An interface is satisfied by the bound type, a struct is created as pointer or bound to an instance of the struct itself.
+func checkTypeAssertion(pass *analysis.Pass, requested types.Type, asserted ast.Expr) {
+	typ := pass.TypesInfo.TypeOf(asserted)
+	// The nil case of a type switch matches a failed request
+	if typ == nil || typ == types.Typ[types.UntypedNil] {
+		return
+	}
+	possible := true
+	switch requested.Underlying().(type) {
+	case *types.Interface:
+		if !types.IsInterface(typ) {
+			possible = types.Implements(typ, requested.Underlying().(*types.Interface))
+		}
+	case *types.Struct:
+		pointer := types.NewPointer(requested)
+		if iface, ok := typ.Underlying().(*types.Interface); ok {
+			possible = types.Implements(requested, iface) || types.Implements(pointer, iface)
+		} else {
+			possible = types.Identical(typ, requested) || types.Identical(typ, pointer)
+		}
+	}
+	if possible {
+		return
+	}
+	flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+		Node:     asserted,
+		Message:  fmt.Sprintf("Impossible Type Assertion! The instance requested as %q can never be of type %q", types.TypeString(requested, facts.PackageName), types.TypeString(typ, facts.PackageName)),
+		Category: "impossibleAssertion",
+	})
+}
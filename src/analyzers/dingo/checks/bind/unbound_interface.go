@@ -0,0 +1,79 @@
+package bind
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+type unboundAnalyzer struct {
+	Analyzer  *analysis.Analyzer
+	Allowlist []string
+}
+
+// NewUnboundAnalyzer creates a new Analyzer which checks that every interface injected without annotation is bound in a module.
+// Interfaces bound by external modules, which aren't part of the analysed packages, can be allowed by the allowlist
+// either by their full name or by their package path.
+// configuration example:
+// unboundInterfaceAllowlist: ["flamingo.me/flamingo/v3/framework/web.Responder", "flamingo.me/flamingo-commerce/v3/cart/domain/cart"]
+func NewUnboundAnalyzer(allowlist []string) *unboundAnalyzer {
+	analyzer := new(unboundAnalyzer)
+	analyzer.Allowlist = allowlist
+	analyzer.Analyzer = &analysis.Analyzer{
+		Name:     "checkUnboundInterface",
+		Doc:      "check that every interface injected by an Inject method, a Provider or an `inject:\"\"` tag is bound in a module",
+		Run:      analyzer.run,
+		Requires: []*analysis.Analyzer{programAnalyzer},
+	}
+	return analyzer
+}
+
+// All modules are known only in the main packages of the applications, the interfaces are checked there.
+// Injections of other packages are reported at the import bringing them in.
+func (a *unboundAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
+	if !facts.IsApplication(pass.Pkg) {
+		return nil, nil
+	}
+	program := pass.ResultOf[programAnalyzer].(*program)
+
+	bound := make(map[string]bool)
+	for _, binding := range program.bindings {
+		bound[binding.key()] = true
+	}
+	for _, dependency := range program.dependencies {
+		if !dependency.Injected || !dependency.Interface || dependency.Collection != "" || bound[dependency.To+" "+strconv.Quote("")] || a.allowed(dependency.To) {
+			continue
+		}
+		node, ok := program.localDependencies[dependency.id()]
+		if !ok {
+			node = program.ImportSpec(pass, dependency.id())
+		}
+		if node == nil {
+			continue
+		}
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     node,
+			Message:  fmt.Sprintf("Unbound Interface! %q is injected as %s (%s) but not bound in any module", dependency.ToName, dependency.Description, dependency.Location),
+			Category: "unboundInterface",
+		})
+	}
+	return nil, nil
+}
+
+// Checks if the interface or its package is part of the allowlist
+func (a *unboundAnalyzer) allowed(typ string) bool {
+	pkgPath := typ
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		pkgPath = typ[:i]
+	}
+	for _, allowed := range a.Allowlist {
+		if allowed == typ || allowed == pkgPath {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"flamingo.me/flamalyzer/src/flamalyzer/log"
+	"golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v2"
+)
+
+// The folder of the flamingo config files
+const configFolder = "config"
+
+type keyAnalyzer struct {
+	Analyzer *analysis.Analyzer
+	Debug    bool
+}
+
+// NewKeyAnalyzer creates a new Analyzer which checks that the config keys injected by inject tags are declared and match the type of the field.
+// Flamingo injects the zero value for an unknown key, so a typo goes unnoticed.
+// If the config of a module can't be read, unknown keys are not reported at all, in debug mode the unreadable functions are logged.
+func NewKeyAnalyzer(debug bool) *keyAnalyzer {
+	analyzer := new(keyAnalyzer)
+	analyzer.Debug = debug
+	analyzer.Analyzer = &analysis.Analyzer{
+		Name:     "checkConfigKeys",
+		Doc:      "check that the config keys of `inject:\"config:...\"` tags are declared by a CueConfig method or in config/*.yml and match the type of the field",
+		Run:      analyzer.run,
+		Requires: []*analysis.Analyzer{declarationsAnalyzer},
+	}
+	return analyzer
+}
+
+// All modules and the config files are known only in the main packages of the applications, the keys are checked there.
+// Keys injected in other packages are reported at the import bringing them in.
+func (a *keyAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
+	if !facts.IsApplication(pass.Pkg) || len(pass.Files) == 0 {
+		return nil, nil
+	}
+	result := pass.ResultOf[declarationsAnalyzer].(*declarations)
+
+	declared := append([]declaration(nil), result.declared...)
+	dir := filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name())
+	declared = append(declared, readConfigFiles(findConfigFolder(dir))...)
+	if len(declared) == 0 {
+		return nil, nil
+	}
+	if len(result.undeclared) > 0 {
+		log.Println(fmt.Sprintf("%s: unknown config keys are not reported, the config declared by %s can't be read",
+			pass.Pkg.Path(), strings.Join(unique(result.undeclared), ", ")), a.Debug)
+	}
+	keys := make(map[string][]declaration)
+	for _, declaration := range declared {
+		keys[declaration.Key] = append(keys[declaration.Key], declaration)
+	}
+
+	for _, usage := range result.usages {
+		message := ""
+		found, typ := lookupKey(keys, usage.Key)
+		switch {
+		case !found && len(result.undeclared) == 0:
+			message = fmt.Sprintf("Unknown Config Key! %q is injected into %s (%s) but not declared by a CueConfig or in %s/*.yml", usage.Key, usage.Description, usage.Location, configFolder)
+		case found && typ != "" && usage.Type != "" && typ != usage.Type:
+			message = fmt.Sprintf("Config Type Mismatch! %q is declared as %s but injected into %s (%s) of type %q", usage.Key, typ, usage.Description, usage.Location, usage.TypeName)
+		default:
+			continue
+		}
+		node, ok := result.localUsages[usage.id()]
+		if !ok {
+			node = result.ImportSpec(pass, usage.id())
+		}
+		if node == nil {
+			continue
+		}
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     node,
+			Message:  message,
+			Category: "configKey",
+		})
+	}
+	return nil, nil
+}
+
+// Returns the sorted names without duplicates
+func unique(names []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Looks up a key and returns its type, the type declared by a CueConfig wins over the type of a value in a config file.
+// A key is declared by a parent struct allowing any keys or of unknown type, too.
+func lookupKey(keys map[string][]declaration, key string) (bool, string) {
+	if declarations, ok := keys[key]; ok {
+		typ := ""
+		for _, declaration := range declarations {
+			if typ == "" || !strings.HasPrefix(declaration.Source, configFolder+"/") {
+				typ = declaration.Type
+			}
+		}
+		return true, typ
+	}
+	for parent := key; strings.Contains(parent, "."); {
+		parent = parent[:strings.LastIndex(parent, ".")]
+		declarations, ok := keys[parent]
+		if !ok {
+			continue
+		}
+		for _, declaration := range declarations {
+			if declaration.Open || declaration.Type == "" {
+				return true, ""
+			}
+		}
+		return false, ""
+	}
+	return false, ""
+}
+
+// Searches the config folder in the given directory and all directories above, up to the directory of the go.mod file
+func findConfigFolder(dir string) string {
+	for {
+		folder := filepath.Join(dir, configFolder)
+		if info, err := os.Stat(folder); err == nil && info.IsDir() {
+			return folder
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Reads the keys of the *.yml files of the config folder, files which can't be read are skipped
+func readConfigFiles(folder string) []declaration {
+	if folder == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(folder, "*.yml"))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(files)
+	var declared []declaration
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var values map[interface{}]interface{}
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			continue
+		}
+		declared = append(declared, yamlDeclarations("", values, configFolder+"/"+filepath.Base(file))...)
+	}
+	return declared
+}
+
+// Returns the keys of the yaml values, dots in keys separate the levels like in nested maps
+func yamlDeclarations(prefix string, values map[interface{}]interface{}, source string) []declaration {
+	var declared []declaration
+	for rawKey, value := range values {
+		key, ok := rawKey.(string)
+		if !ok {
+			continue
+		}
+		parts := strings.Split(key, ".")
+		for i := 1; i < len(parts); i++ {
+			declared = append(declared, declaration{Key: joinKey(prefix, strings.Join(parts[:i], ".")), Type: typeStruct, Source: source})
+		}
+		key = joinKey(prefix, key)
+		typ := ""
+		switch value := value.(type) {
+		case map[interface{}]interface{}:
+			typ = typeStruct
+			declared = append(declared, yamlDeclarations(key, value, source)...)
+		case []interface{}:
+			typ = typeList
+		case bool:
+			typ = typeBool
+		case int, int64, uint64, float64:
+			typ = typeNumber
+		case string:
+			// Placeholders like %%ENV:VARIABLE%% might be of any type
+			if !strings.Contains(value, "%%") {
+				typ = typeString
+			}
+		}
+		declared = append(declared, declaration{Key: key, Type: typ, Source: source})
+	}
+	return declared
+}
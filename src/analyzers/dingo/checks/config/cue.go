@@ -0,0 +1,309 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The types of config values, an empty type is unknown and matches everything
+const (
+	typeString = "string"
+	typeBool   = "bool"
+	typeNumber = "number"
+	typeList   = "list"
+	typeStruct = "struct"
+)
+
+// cueToken is a token of the CUE subset used by CueConfig, newlines separate fields
+type cueToken struct {
+	kind  string // "ident", "string", "number", "newline" or the punctuation itself
+	value string
+}
+
+// Splits the CUE source into tokens, comments are dropped
+func tokenizeCue(source string) ([]cueToken, error) {
+	var tokens []cueToken
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			tokens = append(tokens, cueToken{kind: "newline"})
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			value, err := strconv.Unquote(string(runes[i : end+1]))
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, cueToken{kind: "string", value: value})
+			i = end + 1
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == '_') {
+				end++
+			}
+			tokens = append(tokens, cueToken{kind: "number", value: string(runes[i:end])})
+			i = end
+		case unicode.IsLetter(r) || r == '_' || r == '#' || r == '$':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_' || runes[end] == '#' || runes[end] == '$') {
+				end++
+			}
+			tokens = append(tokens, cueToken{kind: "ident", value: string(runes[i:end])})
+			i = end
+		case strings.HasPrefix(string(runes[i:]), "..."):
+			tokens = append(tokens, cueToken{kind: "..."})
+			i += 3
+		case strings.ContainsRune("{}[]():|&*,?!<>=~.", r):
+			// Operators of constraints like >=0 or =~"^a" are kept as single characters
+			tokens = append(tokens, cueToken{kind: string(r)})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+// The prefixes of terms, i.e. defaults and constraints
+var cueOperators = map[string]bool{"*": true, "<": true, ">": true, "=": true, "!": true, "~": true}
+
+// cueParser collects the config keys declared by a CUE source with their types.
+// It understands the subset of CUE used for config declarations, everything else is an error.
+type cueParser struct {
+	tokens       []cueToken
+	pos          int
+	declarations []declaration
+	source       string
+	// hidden is set while parsing a definition or a hidden field
+	hidden bool
+}
+
+// Parses the CUE source of a CueConfig method into config declarations
+func parseCue(source string, origin string) ([]declaration, error) {
+	tokens, err := tokenizeCue(source)
+	if err != nil {
+		return nil, err
+	}
+	parser := &cueParser{tokens: tokens, source: origin}
+	if err := parser.parseFields("", ""); err != nil {
+		return nil, err
+	}
+	return parser.declarations, nil
+}
+
+func (p *cueParser) peek(offset int) cueToken {
+	if p.pos+offset < len(p.tokens) {
+		return p.tokens[p.pos+offset]
+	}
+	return cueToken{kind: "eof"}
+}
+
+func (p *cueParser) next() cueToken {
+	token := p.peek(0)
+	p.pos++
+	return token
+}
+
+func (p *cueParser) skipSeparators() {
+	for p.peek(0).kind == "newline" || p.peek(0).kind == "," {
+		p.pos++
+	}
+}
+
+// Parses the fields of a struct up to the given closing token, prefix is the key of the struct
+func (p *cueParser) parseFields(prefix string, closing string) error {
+	for {
+		p.skipSeparators()
+		token := p.peek(0)
+		switch {
+		case token.kind == closing || (closing == "" && token.kind == "eof"):
+			p.next()
+			return nil
+		case token.kind == "eof":
+			return fmt.Errorf("missing %q", closing)
+		case token.kind == "...":
+			p.next()
+			p.declare(prefix, typeStruct, true)
+			continue
+		}
+		if err := p.parseField(prefix); err != nil {
+			return err
+		}
+	}
+}
+
+// Parses a field like `a: b: string | *"default"`
+func (p *cueParser) parseField(prefix string) error {
+	key := prefix
+	hidden := false
+	for {
+		label := p.next()
+		if label.kind != "ident" && label.kind != "string" {
+			return fmt.Errorf("unexpected %s %q instead of a label", label.kind, label.value)
+		}
+		// Definitions and hidden fields are no config keys
+		hidden = hidden || strings.HasPrefix(label.value, "#") || strings.HasPrefix(label.value, "_")
+		key = joinKey(key, label.value)
+		if p.peek(0).kind == "?" || p.peek(0).kind == "!" {
+			p.next()
+		}
+		if colon := p.next(); colon.kind != ":" {
+			return fmt.Errorf("missing ':' after %q", label.value)
+		}
+		// Chained labels like `core: auth: {...}`
+		if (p.peek(0).kind == "ident" || p.peek(0).kind == "string") && p.peek(1).kind == ":" {
+			if !hidden {
+				p.declare(key, typeStruct, false)
+			}
+			continue
+		}
+		break
+	}
+	typ, err := p.parseValue(key, hidden)
+	if err != nil {
+		return err
+	}
+	if !hidden {
+		p.declare(key, typ, false)
+	}
+	return nil
+}
+
+// Parses a value with its alternatives and constraints, like `int & >=0 | *1`, and returns its type
+func (p *cueParser) parseValue(key string, hidden bool) (string, error) {
+	typ := ""
+	first := true
+	for {
+		term, err := p.parseTerm(key, hidden)
+		if err != nil {
+			return "", err
+		}
+		if first {
+			typ = term
+		} else if term != "null" && term != "" && typ != term {
+			if typ == "null" || typ == "" {
+				typ = term
+			} else {
+				typ = "mixed"
+			}
+		}
+		first = false
+		if p.peek(0).kind != "|" && p.peek(0).kind != "&" {
+			break
+		}
+		p.next()
+		// Alternatives might continue on the next line
+		for p.peek(0).kind == "newline" {
+			p.next()
+		}
+	}
+	if typ == "null" || typ == "mixed" {
+		typ = ""
+	}
+	return typ, nil
+}
+
+// Parses a single term of a value and returns its type, "null" for null and "" if unknown
+func (p *cueParser) parseTerm(key string, hidden bool) (string, error) {
+	// Defaults and constraints like *"a", >=0 or =~"^a"
+	for cueOperators[p.peek(0).kind] {
+		p.next()
+	}
+	token := p.next()
+	switch token.kind {
+	case "{":
+		if hidden && !p.hidden {
+			p.hidden = true
+			defer func() { p.hidden = false }()
+		}
+		return typeStruct, p.parseFields(key, "}")
+	case "[":
+		return typeList, p.skipBalanced("[", "]")
+	case "(":
+		typ, err := p.parseValue(key, hidden)
+		if err != nil {
+			return "", err
+		}
+		if closing := p.next(); closing.kind != ")" {
+			return "", fmt.Errorf("missing ')'")
+		}
+		return typ, nil
+	case "string":
+		return typeString, nil
+	case "number":
+		return typeNumber, nil
+	case "ident":
+		var typ string
+		switch token.value {
+		case "string", "bytes":
+			typ = typeString
+		case "bool", "true", "false":
+			typ = typeBool
+		case "int", "float", "number", "uint", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			typ = typeNumber
+		case "null":
+			typ = "null"
+		}
+		// References like `strings.MinRunes(3)` or `#Definition` are unknown
+		for p.peek(0).kind == "." && p.peek(1).kind == "ident" {
+			p.pos += 2
+			typ = ""
+		}
+		if p.peek(0).kind == "(" {
+			p.next()
+			return "", p.skipBalanced("(", ")")
+		}
+		return typ, nil
+	}
+	return "", fmt.Errorf("unexpected %s %q in the value of %q", token.kind, token.value, key)
+}
+
+// Skips the tokens up to the closing token matching an already consumed opening token
+func (p *cueParser) skipBalanced(opening string, closing string) error {
+	depth := 1
+	for depth > 0 {
+		token := p.next()
+		switch token.kind {
+		case opening:
+			depth++
+		case closing:
+			depth--
+		case "eof":
+			return fmt.Errorf("missing %q", closing)
+		}
+	}
+	return nil
+}
+
+func (p *cueParser) declare(key string, typ string, open bool) {
+	if key == "" || p.hidden {
+		return
+	}
+	p.declarations = append(p.declarations, declaration{Key: key, Type: typ, Open: open, Source: p.source})
+}
+
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
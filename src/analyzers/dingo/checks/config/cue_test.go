@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTokenizeCue(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "field",
+			source: `enabled: bool | *true`,
+			want:   `ident(enabled) : ident(bool) | * ident(true)`,
+		},
+		{
+			name:   "comments and newlines",
+			source: "a: 1 // the count\n// a comment line\nb: \"x\"",
+			want:   `ident(a) : number(1) newline newline ident(b) : string(x)`,
+		},
+		{
+			name:   "escaped quotes",
+			source: `path: "a\"b"`,
+			want:   `ident(path) : string(a"b)`,
+		},
+		{
+			name:   "numbers",
+			source: `x: 1.5, y: .5, z: 10Ki`,
+			want:   `ident(x) : number(1.5) , ident(y) : number(.5) , ident(z) : number(10Ki)`,
+		},
+		{
+			name:   "definitions, open structs and constraints",
+			source: `#Def: {...}, n?: >=0 & =~"^a"`,
+			want:   `ident(#Def) : { ... } , ident(n) ? : > = number(0) & = ~ string(^a)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenizeCue(tt.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, token := range tokens {
+				if token.kind == "ident" || token.kind == "string" || token.kind == "number" {
+					got = append(got, fmt.Sprintf("%s(%s)", token.kind, token.value))
+				} else {
+					got = append(got, token.kind)
+				}
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("unexpected tokens\n--- want\n%s\n--- got\n%s", tt.want, strings.Join(got, " "))
+			}
+		})
+	}
+}
+
+func TestTokenizeCueErrors(t *testing.T) {
+	for _, source := range []string{`a: "open`, `a: 'single'`, `a: 1 % 2`} {
+		if _, err := tokenizeCue(source); err == nil {
+			t.Errorf("no error for %q", source)
+		}
+	}
+}
+
+func TestParseCue(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "chained labels and alternatives",
+			source: "core: auth: {\n\tenabled: bool | *true\n\tname: string\n\tretries: int & >=0 | *3\n}",
+			want:   []string{"core struct", "core.auth.enabled bool", "core.auth.name string", "core.auth.retries number", "core.auth struct"},
+		},
+		{
+			name:   "mixed, null and referenced types are unknown",
+			source: "a: string | int\nb: null | string\nc: strings.MinRunes(3)\nd: #Def",
+			want:   []string{"a ", "b string", "c ", "d "},
+		},
+		{
+			name:   "lists and open structs",
+			source: `hosts: [...string], labels: {...}, opt?: *"x" | string`,
+			want:   []string{"hosts list", "labels open", "labels struct", "opt string"},
+		},
+		{
+			name:   "definitions and hidden fields are no keys",
+			source: "#Config: {a: string}\n_hidden: b: int\nvisible: (int)",
+			want:   []string{"visible number"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			declared, err := parseCue(tt.source, "(*module.Module).CueConfig")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, declaration := range declared {
+				if declaration.Source != "(*module.Module).CueConfig" {
+					t.Errorf("unexpected source %q of %q", declaration.Source, declaration.Key)
+				}
+				if declaration.Open {
+					got = append(got, declaration.Key+" open")
+				} else {
+					got = append(got, declaration.Key+" "+declaration.Type)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("unexpected declarations\n--- want\n%s\n--- got\n%s", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseCueErrors(t *testing.T) {
+	for _, source := range []string{`a: {b: string`, `a string`, `a: [1, 2`, `a: (int`, `: int`, `a: }`} {
+		if _, err := parseCue(source, "test"); err == nil {
+			t.Errorf("no error for %q", source)
+		}
+	}
+}
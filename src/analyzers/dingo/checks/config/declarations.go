@@ -0,0 +1,268 @@
+package config
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/internal/facts"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// declarationsAnalyzer provides the config keys declared by CueConfig methods and the config keys injected by inject tags
+// of a package, main packages include those of all packages they import. It doesn't report anything on its own.
+var declarationsAnalyzer = &analysis.Analyzer{
+	Name:       "dingoConfig",
+	Doc:        "collect the config keys declared by CueConfig methods and injected by inject tags of a package and in main packages those of all packages they import",
+	Run:        runDeclarationsAnalyzer,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf(new(declarations)),
+	FactTypes:  []analysis.Fact{new(configFact)},
+}
+
+// configFact passes the config declarations and usages of a package on to the main packages.
+// Only the data of the package itself is passed, the main packages collect the facts of all packages they import.
+type configFact struct {
+	Declarations []declaration
+	Usages       []usage
+	// Undeclared are the functions declaring config which can't be read, e.g. a CueConfig method not returning a constant
+	Undeclared []string
+	// Imports are the paths of the imported packages having a configFact, so the import bringing in a usage can be found
+	Imports []string
+}
+
+// AFact marks configFact as analysis.Fact
+func (*configFact) AFact() {}
+
+// ImportPaths marks configFact as facts.Fact
+func (f *configFact) ImportPaths() []string {
+	return f.Imports
+}
+
+func (f *configFact) String() string {
+	return fmt.Sprintf("config(%d declarations, %d usages, %d imports)", len(f.Declarations), len(f.Usages), len(f.Imports))
+}
+
+// declaration is a config key declared by a CueConfig method or a config file
+type declaration struct {
+	Key string
+	// Type is one of the config value types, empty if unknown
+	Type string
+	// Open is set for structs allowing any further keys, like `{...}` in CUE
+	Open bool
+	// Source is the function or file declaring the key
+	Source string
+}
+
+func (d declaration) id() string {
+	return d.Source + " " + d.Key
+}
+
+// usage is a config key injected by an inject tag like `inject:"config:some.key"`
+type usage struct {
+	Location facts.Location
+	Key      string
+	// Type is the config value type the field can take, empty if it can take any, TypeName is the type of the field
+	Type     string
+	TypeName string
+	// Description tells where the key is injected, e.g. `field "Debug" of "module.Config"`
+	Description string
+}
+
+func (u usage) id() string {
+	return fmt.Sprintf("usage %s:%d:%d %s", u.Location.Filename, u.Location.Line, u.Location.Column, u.Key)
+}
+
+// declarations is the result of the declarationsAnalyzer
+type declarations struct {
+	// declared keys and usages of this package, in main packages also those of the imported packages,
+	// undeclared are the functions whose config is unknown
+	declared   []declaration
+	usages     []usage
+	undeclared []string
+	// the nodes of the usages of this package by their id
+	localUsages map[string]ast.Node
+	// locates the usages of the imported packages
+	facts.Imports
+}
+
+// Collects the config declarations and usages of this package, main packages add those of all imported packages
+func runDeclarationsAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	result := &declarations{
+		localUsages: make(map[string]ast.Node),
+	}
+	own := new(configFact)
+	own.Declarations, own.Undeclared = collectDeclarations(pass)
+	own.Usages = collectUsages(pass, result.localUsages)
+
+	own.Imports = facts.ImportPaths(pass, new(configFact))
+	if facts.IsApplication(pass.Pkg) {
+		result.addImported(pass, own.Imports)
+	}
+	result.declared = append(result.declared, own.Declarations...)
+	result.usages = append(result.usages, own.Usages...)
+	result.undeclared = append(result.undeclared, own.Undeclared...)
+
+	if len(own.Declarations) > 0 || len(own.Usages) > 0 || len(own.Undeclared) > 0 || len(own.Imports) > 0 {
+		pass.ExportPackageFact(own)
+	}
+	return result, nil
+}
+
+// Adds the declarations and usages of all packages reached by the given imports
+func (d *declarations) addImported(pass *analysis.Pass, imports []string) {
+	d.Imports = facts.Merge(pass, imports, func(fact *configFact) []string {
+		var ids []string
+		d.declared = append(d.declared, fact.Declarations...)
+		for _, usage := range fact.Usages {
+			ids = append(ids, usage.id())
+			d.usages = append(d.usages, usage)
+		}
+		d.undeclared = append(d.undeclared, fact.Undeclared...)
+		return ids
+	})
+}
+
+// Collects the keys declared by the CueConfig methods of the package.
+// CueConfig methods not returning a constant and the legacy DefaultConfig methods can't be read, they are returned as undeclared.
+func collectDeclarations(pass *analysis.Pass) ([]declaration, []string) {
+	var declared []declaration
+	var undeclared []string
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok || decl.Recv == nil || decl.Body == nil {
+			return
+		}
+		switch decl.Name.Name {
+		case "DefaultConfig":
+			undeclared = append(undeclared, facts.FunctionName(fn))
+		case "CueConfig":
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				if _, ok := n.(*ast.FuncLit); ok {
+					return false
+				}
+				ret, ok := n.(*ast.ReturnStmt)
+				if !ok || len(ret.Results) != 1 {
+					return true
+				}
+				value := pass.TypesInfo.Types[ret.Results[0]].Value
+				if value == nil || value.Kind() != constant.String {
+					undeclared = append(undeclared, facts.FunctionName(fn))
+					return false
+				}
+				cue, err := parseCue(constant.StringVal(value), facts.FunctionName(fn))
+				if err != nil {
+					undeclared = append(undeclared, facts.FunctionName(fn))
+					return false
+				}
+				declared = append(declared, cue...)
+				return false
+			})
+		}
+	})
+	return declared, undeclared
+}
+
+// Collects the config keys injected by inject tags like `inject:"config:some.key,optional"` of all structs of the package
+func collectUsages(pass *analysis.Pass, nodes map[string]ast.Node) []usage {
+	var usages []usage
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.StructType)(nil),
+	}
+	input.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		structType := n.(*ast.StructType)
+		for _, field := range structType.Fields.List {
+			key, ok := configKey(field)
+			if !ok {
+				continue
+			}
+			typ := pass.TypesInfo.TypeOf(field.Type)
+			if typ == nil {
+				continue
+			}
+			for _, name := range field.Names {
+				usage := usage{
+					Location:    facts.NewLocation(pass.Fset, field.Tag.Pos()),
+					Key:         key,
+					Type:        valueType(typ),
+					TypeName:    types.TypeString(typ, facts.PackageName),
+					Description: describeField(pass, name.Name, stack),
+				}
+				nodes[usage.id()] = field.Tag
+				usages = append(usages, usage)
+			}
+		}
+		return true
+	})
+	return usages
+}
+
+// Returns the config key of a field tagged like `inject:"config:some.key"`
+func configKey(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	value, ok := reflect.StructTag(tag).Lookup("inject")
+	if !ok {
+		return "", false
+	}
+	annotation := strings.Split(value, ",")[0]
+	if !strings.HasPrefix(annotation, "config:") {
+		return "", false
+	}
+	return strings.TrimPrefix(annotation, "config:"), true
+}
+
+// Returns the config value type a field of the given type can take, empty if it can take any
+func valueType(typ types.Type) string {
+	switch underlying := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case underlying.Info()&types.IsBoolean != 0:
+			return typeBool
+		case underlying.Info()&types.IsString != 0:
+			return typeString
+		case underlying.Info()&types.IsNumeric != 0:
+			return typeNumber
+		}
+	case *types.Slice:
+		return typeList
+	case *types.Map:
+		return typeStruct
+	}
+	return ""
+}
+
+// Describes the field by the declaration of its struct, anonymous structs are described by the enclosing function
+func describeField(pass *analysis.Pass, name string, stack []ast.Node) string {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.TypeSpec:
+			return fmt.Sprintf("field %q of %q", name, pass.Pkg.Name()+"."+node.Name.Name)
+		case *ast.FuncDecl:
+			if fn, ok := pass.TypesInfo.Defs[node.Name].(*types.Func); ok {
+				return fmt.Sprintf("field %q in %s", name, facts.FunctionName(fn))
+			}
+		}
+	}
+	return fmt.Sprintf("field %q", name)
+}
@@ -39,15 +39,16 @@ func runReceiverAnalyzer(pass *analysis.Pass) (interface{}, error) {
 			for _, rec := range funcdecl.Recv.List {
 				var _, isStarType = rec.Type.(*ast.StarExpr)
 
-				// If it is an inject-function but the pointer(*) is missing, report with a suggested fix
+				// If it is an inject-function but the pointer(*) is missing, report with a suggested fix.
+				// The receiver might be unnamed or of a generic type like `Repository[T]`, so the pointer is inserted before the type.
 				if !isStarType {
 					suggestedFix := &analysis.SuggestedFix{
 						Message: "Add missing Pointer",
 						TextEdits: []analysis.TextEdit{
 							{
-								Pos:     rec.Pos(),
-								End:     rec.End(),
-								NewText: []byte(rec.Names[0].Name + " *" + rec.Type.(*ast.Ident).Name),
+								Pos:     rec.Type.Pos(),
+								End:     rec.Type.Pos(),
+								NewText: []byte("*"),
 							},
 						},
 					}
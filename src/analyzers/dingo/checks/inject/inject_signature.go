@@ -0,0 +1,216 @@
+package inject
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+)
+
+type signatureAnalyzer struct {
+	Analyzer   *analysis.Analyzer
+	AllowLogic bool
+}
+
+// NewSignatureAnalyzer creates a new Analyzer which checks the conventions of Inject methods:
+// - the only allowed result is the receiver itself for fluent calls
+// - parameters are pointers or interfaces instead of structs passed by value
+// - every parameter is used
+// - the body only assigns the parameters to fields of the receiver, unless logic is allowed
+// configuration example:
+// allowInjectLogic: true
+func NewSignatureAnalyzer(allowLogic bool) *signatureAnalyzer {
+	analyzer := new(signatureAnalyzer)
+	analyzer.AllowLogic = allowLogic
+	analyzer.Analyzer = &analysis.Analyzer{
+		Name:     "checkInjectSignature",
+		Doc:      "check if the Inject method only returns its receiver, takes pointers or interfaces, uses all parameters and only assigns them to fields",
+		Run:      analyzer.run,
+		Requires: []*analysis.Analyzer{ReceiverAnalyzer},
+	}
+	return analyzer
+}
+
+// Checks the Inject methods with a pointer receiver provided by the ReceiverAnalyzer
+func (a *signatureAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
+	injectFunctions := pass.ResultOf[ReceiverAnalyzer].([]*ast.FuncDecl)
+	for _, injectFunc := range injectFunctions {
+		var receiver types.Object
+		if names := injectFunc.Recv.List[0].Names; len(names) > 0 {
+			receiver = pass.TypesInfo.Defs[names[0]]
+		}
+		validResults := checkResults(pass, injectFunc)
+		checkParams(pass, injectFunc)
+		// The returns of invalid results are reported already
+		if !a.AllowLogic && validResults && injectFunc.Body != nil {
+			checkAssignmentsOnly(pass, receiver, injectFunc.Body.List)
+		}
+	}
+	return nil, nil
+}
+
+// The only result allowed is the receiver itself, like `func (s *Service) Inject(...) *Service`
+func checkResults(pass *analysis.Pass, injectFunc *ast.FuncDecl) bool {
+	results := injectFunc.Type.Results
+	if results == nil || len(results.List) == 0 {
+		return true
+	}
+	receiverType := pass.TypesInfo.TypeOf(injectFunc.Recv.List[0].Type)
+	if results.NumFields() == 1 && types.Identical(pass.TypesInfo.TypeOf(results.List[0].Type), receiverType) {
+		return true
+	}
+	flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+		Node:     results,
+		Message:  fmt.Sprintf("Incorrect Inject Signature! The Inject method must not return anything else than its receiver %q", types.TypeString(receiverType, packageName)),
+		Category: "injectSignature",
+	})
+	return false
+}
+
+// Parameters must be pointers or interfaces and must be used in the body
+func checkParams(pass *analysis.Pass, injectFunc *ast.FuncDecl) {
+	used := make(map[types.Object]bool)
+	if injectFunc.Body != nil {
+		ast.Inspect(injectFunc.Body, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				used[pass.TypesInfo.Uses[ident]] = true
+			}
+			return true
+		})
+	}
+
+	for _, param := range injectFunc.Type.Params.List {
+		typ := pass.TypesInfo.TypeOf(param.Type)
+		if _, ok := typ.Underlying().(*types.Struct); ok {
+			flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+				Node:     param.Type,
+				Message:  fmt.Sprintf("Incorrect Inject Signature! The struct %q is injected by value, use a pointer or an interface instead", types.TypeString(typ, packageName)),
+				Category: "injectSignature",
+			})
+		}
+		if len(param.Names) == 0 {
+			reportUnusedParam(pass, param.Type, types.TypeString(typ, packageName))
+		}
+		for _, name := range param.Names {
+			if obj := pass.TypesInfo.Defs[name]; obj == nil || !used[obj] {
+				reportUnusedParam(pass, name, name.Name)
+			}
+		}
+	}
+}
+
+func reportUnusedParam(pass *analysis.Pass, node ast.Node, name string) {
+	flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+		Node:     node,
+		Message:  fmt.Sprintf("Incorrect Inject Signature! The parameter %q is never used, remove it or assign it to a field", name),
+		Category: "injectSignature",
+	})
+}
+
+// Reports every statement which is not an assignment to a field of the receiver.
+// Returning the receiver and checking an optional parameter for nil are allowed as well.
+func checkAssignmentsOnly(pass *analysis.Pass, receiver types.Object, statements []ast.Stmt) {
+	for _, statement := range statements {
+		if isAllowedStatement(pass, receiver, statement) {
+			continue
+		}
+		flanalysis.ReportDiagnostic(pass, flanalysis.Diagnostic{
+			Node:     statement,
+			Message:  "Incorrect Inject Signature! The Inject method should only assign its parameters to fields, move the logic to the methods using them",
+			Category: "injectLogic",
+		})
+	}
+}
+
+func isAllowedStatement(pass *analysis.Pass, receiver types.Object, statement ast.Stmt) bool {
+	switch stmt := statement.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok != token.ASSIGN {
+			return false
+		}
+		for _, lhs := range stmt.Lhs {
+			if !isReceiverField(pass, receiver, lhs) {
+				return false
+			}
+		}
+		for _, rhs := range stmt.Rhs {
+			if !isPlainValue(pass, rhs) {
+				return false
+			}
+		}
+		return true
+	case *ast.ReturnStmt:
+		for _, result := range stmt.Results {
+			if ident, ok := result.(*ast.Ident); !ok || receiver == nil || pass.TypesInfo.Uses[ident] != receiver {
+				return false
+			}
+		}
+		return true
+	case *ast.IfStmt:
+		if stmt.Init != nil || !isNilCheck(pass, stmt.Cond) {
+			return false
+		}
+		checkAssignmentsOnly(pass, receiver, stmt.Body.List)
+		if stmt.Else != nil {
+			checkAssignmentsOnly(pass, receiver, []ast.Stmt{stmt.Else})
+		}
+		return true
+	case *ast.BlockStmt:
+		checkAssignmentsOnly(pass, receiver, stmt.List)
+		return true
+	case *ast.EmptyStmt:
+		return true
+	}
+	return false
+}
+
+// Checks if the expression is a field of the receiver, like `s.field` or `s.config.field`
+func isReceiverField(pass *analysis.Pass, receiver types.Object, expr ast.Expr) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok || receiver == nil {
+		return false
+	}
+	for {
+		switch x := selector.X.(type) {
+		case *ast.Ident:
+			return pass.TypesInfo.Uses[x] == receiver
+		case *ast.SelectorExpr:
+			selector = x
+		default:
+			return false
+		}
+	}
+}
+
+// Checks if the expression doesn't call a function, type conversions are fine
+func isPlainValue(pass *analysis.Pass, expr ast.Expr) bool {
+	plain := true
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if !pass.TypesInfo.Types[node.Fun].IsType() {
+				plain = false
+			}
+		case *ast.FuncLit:
+			plain = false
+		}
+		return plain
+	})
+	return plain
+}
+
+// Checks if the condition compares a value to nil, like `if config != nil`
+func isNilCheck(pass *analysis.Pass, cond ast.Expr) bool {
+	binary, ok := cond.(*ast.BinaryExpr)
+	if !ok || (binary.Op != token.EQL && binary.Op != token.NEQ) {
+		return false
+	}
+	return pass.TypesInfo.Types[binary.X].IsNil() || pass.TypesInfo.Types[binary.Y].IsNil()
+}
+
+func packageName(pkg *types.Package) string {
+	return pkg.Name()
+}
@@ -2,7 +2,10 @@ package inject
 
 import (
 	"go/ast"
-	"regexp"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
 
 	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
 	"golang.org/x/tools/go/analysis"
@@ -23,82 +26,101 @@ var TagAnalyzer = &analysis.Analyzer{
 	Requires: []*analysis.Analyzer{inspect.Analyzer, ReceiverAnalyzer},
 }
 
-type structObject struct {
-	typeSpec   *ast.TypeSpec
-	structType *ast.StructType
-	fieldList  []*ast.Field
-}
+// The options dingo supports after the annotation of an inject tag
+var injectTagOptions = map[string]bool{"optional": true}
 
 // "inject tags" should be used for config injection only, otherwise inject method should be used.
 func runTagAnalyzer(pass *analysis.Pass) (interface{}, error) {
 	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-	// Using the results of the boundToReference.TagAnalyzer who provides all Inject-Functions
+	// Using the results of the ReceiverAnalyzer who provides all Inject-Functions
 	injectFunctions := pass.ResultOf[ReceiverAnalyzer].([]*ast.FuncDecl)
 
+	// The structs declared inside the parameters of Inject-Functions are referenced by definition
+	referenced := referencedStructs(pass, injectFunctions)
+	for _, injectFunc := range injectFunctions {
+		for _, param := range injectFunc.Type.Params.List {
+			ast.Inspect(param.Type, func(n ast.Node) bool {
+				if structType, ok := n.(*ast.StructType); ok {
+					checkInjectTags(pass, structType, true)
+					return false
+				}
+				return true
+			})
+		}
+	}
+
 	nodeFilter := []ast.Node{
 		(*ast.TypeSpec)(nil),
 	}
-	checkFunction := func(n ast.Node) {
-		// Check regex at https://www.debuggex.com/#cheatsheet
-		expFindInject := regexp.MustCompile("(`(inject:)[\"].*\"`)|(\"(inject:)[`].*`\")")
-		expFindEmptyInject := regexp.MustCompile("(`(inject:)[\"]\"`)|(\"(inject:)[`]`\")")
-
-		typeObject := n.(*ast.TypeSpec)
-		var structObject = structObject{}
-
-		// If found type is a struct-type go on, else stop here
-		if assertion, ok := typeObject.Type.(*ast.StructType); ok {
-			structObject.structType = assertion
-			structObject.typeSpec = typeObject
-		} else {
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		typeSpec := n.(*ast.TypeSpec)
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok || typeSpec.Assign.IsValid() {
 			return
 		}
+		obj, ok := pass.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+		checkInjectTags(pass, structType, ok && referenced[obj])
+	})
+	return nil, nil
+}
 
-		// Search for tags in the given struct, if there is a tag, safe them to the fieldList
-		for _, f := range structObject.structType.Fields.List {
-			if f.Tag != nil {
-				if expFindInject.FindStringSubmatch(f.Tag.Value) != nil {
-					structObject.fieldList = append(structObject.fieldList, f)
-				}
-			}
+// Returns the named structs injected as parameter of an Inject-Function, together with the structs they embed
+func referencedStructs(pass *analysis.Pass, injectFunctions []*ast.FuncDecl) map[*types.TypeName]bool {
+	referenced := make(map[*types.TypeName]bool)
+	var reference func(typ types.Type)
+	reference = func(typ types.Type) {
+		if pointer, ok := typ.(*types.Pointer); ok {
+			typ = pointer.Elem()
 		}
-		// If struct don't have fields with inject-tags stop here
-		if len(structObject.fieldList) == 0 {
+		named, ok := typ.(*types.Named)
+		if !ok || referenced[named.Obj()] {
 			return
 		}
-		// Evaluate the Inject-Tags
-		for _, field := range structObject.fieldList {
-			if expFindEmptyInject.FindStringSubmatch(field.Tag.Value) != nil {
-				flanalysis.Report(pass, "Empty Inject-Tags are not allowed! Add more specific naming or use the Inject function for non configuration injections", field.Tag)
-			} else {
-				if !isTagReferencedInInjectMethod(structObject, field, injectFunctions) {
-					flanalysis.Report(pass, "Injections should be referenced in the Inject function! References in the Inject-Function should be found in the same package!", field.Tag)
-				}
+		structType, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return
+		}
+		referenced[named.Obj()] = true
+		for i := 0; i < structType.NumFields(); i++ {
+			if structType.Field(i).Embedded() {
+				reference(structType.Field(i).Type())
 			}
 		}
 	}
-	input.Preorder(nodeFilter, checkFunction)
-	return nil, nil
-}
-
-// If a inject tag is outside the type must be referenced in the Inject-Function
-func isTagReferencedInInjectMethod(structEntity structObject, field *ast.Field, injectFunctions []*ast.FuncDecl) bool {
-	if injectFunctions == nil {
-		return false
-	}
-	// Check against all Inject-Functions in the Package
 	for _, injectFunc := range injectFunctions {
 		for _, param := range injectFunc.Type.Params.List {
-			// If parameter has a pointer
-			if param, ok := param.Type.(*ast.StarExpr); ok {
-				// If the parameter has no selectors, meaning he is directly defined in the current package, compare their name and return true if they are the same.
-				if param, ok := param.X.(*ast.Ident); ok {
-					if param.Name == structEntity.typeSpec.Name.Name {
-						return true
-					}
-				}
+			if typ := pass.TypesInfo.TypeOf(param.Type); typ != nil {
+				reference(typ)
 			}
 		}
 	}
-	return false
+	return referenced
+}
+
+// Checks the inject tags of the fields of a struct, a struct which is not referenced by an Inject-Function must not have any
+func checkInjectTags(pass *analysis.Pass, structType *ast.StructType, referenced bool) {
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		value, ok := reflect.StructTag(tag).Lookup("inject")
+		if !ok {
+			continue
+		}
+		options := strings.Split(value, ",")
+		for _, option := range options[1:] {
+			if !injectTagOptions[option] {
+				flanalysis.Report(pass, "Unknown Inject-Tag option %q! Only \"optional\" is supported", field.Tag, option)
+			}
+		}
+		if options[0] == "" {
+			flanalysis.Report(pass, "Empty Inject-Tags are not allowed! Add more specific naming or use the Inject function for non configuration injections", field.Tag)
+		} else if !referenced {
+			flanalysis.Report(pass, "Injections should be referenced in the Inject function! References in the Inject-Function should be found in the same package!", field.Tag)
+		}
+	}
 }
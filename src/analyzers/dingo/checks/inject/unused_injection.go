@@ -0,0 +1,306 @@
+package inject
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	flanalysis "flamingo.me/flamalyzer/src/flamalyzer/analysis"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// UnusedAnalyzer checks that injected dependencies are read somewhere in the package.
+// An unused injection needs a binding nobody benefits from and hides dead code.
+var UnusedAnalyzer = &analysis.Analyzer{
+	Name:     "checkUnusedInjection",
+	Doc:      "check that the dependencies injected by an Inject method or an inject tag are read in the package, remove unused injections",
+	Run:      runUnusedAnalyzer,
+	Requires: []*analysis.Analyzer{inspect.Analyzer, ReceiverAnalyzer},
+}
+
+// Only fields which can't be read by other packages are checked, i.e. unexported fields or fields of unexported and anonymous structs.
+func runUnusedAnalyzer(pass *analysis.Pass) (interface{}, error) {
+	input := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	injectFunctions := pass.ResultOf[ReceiverAnalyzer].([]*ast.FuncDecl)
+	read := readFields(pass, input)
+	referenced := referencedFuncs(pass)
+
+	for _, injectFunc := range injectFunctions {
+		checkInjectParams(pass, injectFunc, read, referenced)
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.StructType)(nil),
+	}
+	input.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		// The fields of a named struct are exported by the struct, anonymous structs are private to their parameter
+		exportedStruct := false
+		if typeSpec, ok := stack[len(stack)-2].(*ast.TypeSpec); ok {
+			exportedStruct = typeSpec.Name.IsExported()
+		}
+		checkInjectTagFields(pass, n.(*ast.StructType), exportedStruct, read)
+		return true
+	})
+	return nil, nil
+}
+
+// Returns the fields read in the package, assigning a field is no read
+func readFields(pass *analysis.Pass, input *inspector.Inspector) map[*types.Var]bool {
+	written := make(map[*ast.SelectorExpr]bool)
+	read := make(map[*types.Var]bool)
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.SelectorExpr)(nil),
+	}
+	// The assignments are visited before their selectors
+	input.Preorder(nodeFilter, func(n ast.Node) {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if node.Tok != token.ASSIGN {
+				return
+			}
+			for _, lhs := range node.Lhs {
+				if selector, ok := lhs.(*ast.SelectorExpr); ok {
+					written[selector] = true
+				}
+			}
+		case *ast.SelectorExpr:
+			selection, ok := pass.TypesInfo.Selections[node]
+			if !ok || selection.Kind() == types.MethodExpr {
+				return
+			}
+			// A promoted field or method reads the embedded fields on its path, even if the promoted field is assigned
+			for _, field := range embeddedFields(selection) {
+				read[field] = true
+			}
+			if selection.Kind() != types.FieldVal || written[node] {
+				return
+			}
+			// Each method of a generic type has its own instance of the fields, they are compared by their origin
+			if field, ok := selection.Obj().(*types.Var); ok {
+				read[field.Origin()] = true
+			}
+		}
+	})
+	return read
+}
+
+// Returns the embedded fields the selection passes through, like the field Responder of `c.Render()` for `struct{ *Responder }`
+func embeddedFields(selection *types.Selection) []*types.Var {
+	var fields []*types.Var
+	typ := selection.Recv()
+	path := selection.Index()
+	for _, index := range path[:len(path)-1] {
+		if pointer, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = pointer.Elem()
+		}
+		structType, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+		field := structType.Field(index)
+		fields = append(fields, field.Origin())
+		typ = field.Type()
+	}
+	return fields
+}
+
+// Returns the functions and methods the package calls or refers to explicitly, e.g. an Inject method called by a constructor
+func referencedFuncs(pass *analysis.Pass) map[*types.Func]bool {
+	referenced := make(map[*types.Func]bool)
+	for _, obj := range pass.TypesInfo.Uses {
+		if fn, ok := obj.(*types.Func); ok {
+			referenced[fn.Origin()] = true
+		}
+	}
+	return referenced
+}
+
+// Reports parameters of the Inject method which are only assigned to fields which are never read.
+// Removing a parameter breaks explicit calls of the method, so the fix is suggested only if the package doesn't refer to it.
+func checkInjectParams(pass *analysis.Pass, injectFunc *ast.FuncDecl, read map[*types.Var]bool, referenced map[*types.Func]bool) {
+	if injectFunc.Body == nil || len(injectFunc.Recv.List[0].Names) == 0 {
+		return
+	}
+	receiver := pass.TypesInfo.Defs[injectFunc.Recv.List[0].Names[0]]
+	injectFn, _ := pass.TypesInfo.Defs[injectFunc.Name].(*types.Func)
+	exportedReceiver := false
+	if named, ok := derefNamed(receiver.Type()); ok {
+		exportedReceiver = named.Obj().Exported()
+	}
+
+	// The assignments of the parameters to fields of the receiver, other uses of the parameters
+	assignments := make(map[types.Object][]*ast.AssignStmt)
+	fields := make(map[types.Object][]*types.Var)
+	used := make(map[types.Object]bool)
+	ast.Inspect(injectFunc.Body, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok && assign.Tok == token.ASSIGN && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 {
+			field := receiverField(pass, receiver, assign.Lhs[0])
+			ident, isIdent := assign.Rhs[0].(*ast.Ident)
+			if field != nil && isIdent && pass.TypesInfo.Uses[ident] != nil {
+				param := pass.TypesInfo.Uses[ident]
+				assignments[param] = append(assignments[param], assign)
+				fields[param] = append(fields[param], field)
+				return false
+			}
+		}
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] != nil {
+			used[pass.TypesInfo.Uses[ident]] = true
+		}
+		return true
+	})
+
+	// All unused parameters are removed by one fix, the edits of adjacent parameters would overlap otherwise
+	var diagnostics []flanalysis.Diagnostic
+	var fixable []int
+	var removed []int
+	var edits []analysis.TextEdit
+	params := injectFunc.Type.Params.List
+	for i, param := range params {
+		for _, name := range param.Names {
+			obj := pass.TypesInfo.Defs[name]
+			if obj == nil || used[obj] || len(fields[obj]) == 0 {
+				continue
+			}
+			unread := true
+			for _, field := range fields[obj] {
+				unread = unread && !read[field] && (!field.Exported() || !exportedReceiver)
+			}
+			if !unread {
+				continue
+			}
+			diagnostics = append(diagnostics, flanalysis.Diagnostic{
+				Node:     name,
+				Message:  fmt.Sprintf("Unused Injection! %q is injected into the field %q by %s but the field is never read", name.Name, fields[obj][0].Name(), functionName(pass, injectFunc)),
+				Category: "unusedInjection",
+			})
+			// The parameter can only be removed on its own if it doesn't share its type with other parameters
+			if len(param.Names) == 1 && !referenced[injectFn] {
+				fixable = append(fixable, len(diagnostics)-1)
+				removed = append(removed, i)
+				for _, assign := range assignments[obj] {
+					edits = append(edits, removeLines(pass, assign))
+				}
+			}
+		}
+	}
+	if len(removed) > 0 {
+		fix := analysis.SuggestedFix{Message: "Remove the unused injections of " + functionName(pass, injectFunc), TextEdits: append(removeParams(params, removed), edits...)}
+		for _, index := range fixable {
+			diagnostics[index].SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+	}
+	for _, diagnostic := range diagnostics {
+		flanalysis.ReportDiagnostic(pass, diagnostic)
+	}
+}
+
+// Reports fields with an inject tag which are never read
+func checkInjectTagFields(pass *analysis.Pass, structType *ast.StructType, exportedStruct bool, read map[*types.Var]bool) {
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		if _, ok := reflect.StructTag(tag).Lookup("inject"); !ok {
+			continue
+		}
+		for _, name := range field.Names {
+			obj, ok := pass.TypesInfo.Defs[name].(*types.Var)
+			if !ok || read[obj] || (exportedStruct && obj.Exported()) || name.Name == "_" {
+				continue
+			}
+			diagnostic := flanalysis.Diagnostic{
+				Node:     name,
+				Message:  fmt.Sprintf("Unused Injection! The field %q is injected by its inject tag but never read", name.Name),
+				Category: "unusedInjection",
+			}
+			if len(field.Names) == 1 {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{{Message: "Remove the unused injection", TextEdits: []analysis.TextEdit{removeLines(pass, field)}}}
+			}
+			flanalysis.ReportDiagnostic(pass, diagnostic)
+		}
+	}
+}
+
+// Returns the field of the receiver assigned by the expression, like `s.field`
+func receiverField(pass *analysis.Pass, receiver types.Object, expr ast.Expr) *types.Var {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if ident, ok := selector.X.(*ast.Ident); !ok || pass.TypesInfo.Uses[ident] != receiver {
+		return nil
+	}
+	selection, ok := pass.TypesInfo.Selections[selector]
+	if !ok || selection.Kind() != types.FieldVal {
+		return nil
+	}
+	field, ok := selection.Obj().(*types.Var)
+	if !ok {
+		return nil
+	}
+	return field.Origin()
+}
+
+func derefNamed(typ types.Type) (*types.Named, bool) {
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	return named, ok
+}
+
+// Removes the parameters with the given ascending indices together with their separating commas.
+// Adjacent parameters are removed by one edit, so the edits don't overlap.
+func removeParams(params []*ast.Field, indices []int) []analysis.TextEdit {
+	var edits []analysis.TextEdit
+	for start := 0; start < len(indices); {
+		end := start
+		for end+1 < len(indices) && indices[end+1] == indices[end]+1 {
+			end++
+		}
+		first, last := indices[start], indices[end]
+		switch {
+		case last < len(params)-1:
+			edits = append(edits, analysis.TextEdit{Pos: params[first].Pos(), End: params[last+1].Pos()})
+		case first > 0:
+			edits = append(edits, analysis.TextEdit{Pos: params[first-1].End(), End: params[last].End()})
+		default:
+			edits = append(edits, analysis.TextEdit{Pos: params[first].Pos(), End: params[last].End()})
+		}
+		start = end + 1
+	}
+	return edits
+}
+
+// Removes the lines of the node, including the comment following it on the last line
+func removeLines(pass *analysis.Pass, node ast.Node) analysis.TextEdit {
+	file := pass.Fset.File(node.Pos())
+	start := file.LineStart(file.Line(node.Pos()))
+	end := token.Pos(file.Base() + file.Size())
+	if line := file.Line(node.End()); line < file.LineCount() {
+		end = file.LineStart(line + 1)
+	}
+	return analysis.TextEdit{Pos: start, End: end}
+}
+
+// Returns the name of the Inject method like "(*pkg.T).Inject"
+func functionName(pass *analysis.Pass, injectFunc *ast.FuncDecl) string {
+	fn, ok := pass.TypesInfo.Defs[injectFunc.Name].(*types.Func)
+	if !ok {
+		return injectFunc.Name.Name
+	}
+	return fmt.Sprintf("(%s).%s", types.TypeString(fn.Type().(*types.Signature).Recv().Type(), packageName), fn.Name())
+}
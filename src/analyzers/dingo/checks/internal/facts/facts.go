@@ -0,0 +1,166 @@
+// Package facts passes the data the dingo checks collect in a package on to the main packages of the applications.
+// Every package exports only its own data, the main packages merge the facts of all packages they import.
+package facts
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Fact is a package fact holding only the data of its own package
+type Fact interface {
+	analysis.Fact
+	// ImportPaths are the paths of the imported packages having the same fact
+	ImportPaths() []string
+}
+
+// Location identifies a declaration across packages, token.Pos can't be passed on to other packages
+type Location struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+// NewLocation returns the Location of the position
+func NewLocation(fset *token.FileSet, pos token.Pos) Location {
+	position := fset.Position(pos)
+	return Location{Filename: position.Filename, Line: position.Line, Column: position.Column}
+}
+
+// Describes the location for messages
+func (l Location) String() string {
+	return fmt.Sprintf("%s:%d", filepath.Base(l.Filename), l.Line)
+}
+
+// IsApplication checks if the package is the main package of an application, where all modules come together.
+// The main packages generated for tests don't combine the modules of an application.
+func IsApplication(pkg *types.Package) bool {
+	return pkg.Name() == "main" && !strings.HasSuffix(pkg.Path(), ".test")
+}
+
+// ImportPaths returns the paths of the packages imported by the package of the pass which have a fact of the given type,
+// sorted by path. The given fact is overwritten by the imported facts.
+func ImportPaths(pass *analysis.Pass, fact analysis.Fact) []string {
+	imports := append([]*types.Package(nil), pass.Pkg.Imports()...)
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].Path() < imports[j].Path()
+	})
+	var paths []string
+	for _, pkg := range imports {
+		if pass.ImportPackageFact(pkg, fact) {
+			paths = append(paths, pkg.Path())
+		}
+	}
+	return paths
+}
+
+// Imports locates the data merged from the imported packages
+type Imports struct {
+	// the package declaring each id of the imported packages and the packages reached by every import
+	declaredIn map[string]string
+	reaches    map[string]map[string]bool
+}
+
+// Merge calls add for the facts of all packages reached by the given imports, the imported packages come before the packages
+// importing them. add returns the ids of the data it takes from the fact, so the import bringing them in can be found.
+func Merge[F Fact](pass *analysis.Pass, imports []string, add func(fact F) []string) Imports {
+	merged := Imports{
+		declaredIn: make(map[string]string),
+		reaches:    make(map[string]map[string]bool),
+	}
+	facts := make(map[string]F)
+	for _, packageFact := range pass.AllPackageFacts() {
+		if fact, ok := packageFact.Fact.(F); ok {
+			facts[packageFact.Package.Path()] = fact
+		}
+	}
+
+	added := make(map[string]bool)
+	var addPackage func(pkg string)
+	addPackage = func(pkg string) {
+		fact, ok := facts[pkg]
+		if added[pkg] || !ok {
+			return
+		}
+		added[pkg] = true
+		for _, imported := range fact.ImportPaths() {
+			addPackage(imported)
+		}
+		for _, id := range add(fact) {
+			merged.declaredIn[id] = pkg
+		}
+	}
+	for _, pkg := range imports {
+		addPackage(pkg)
+	}
+
+	for _, pkg := range imports {
+		reached := make(map[string]bool)
+		var reach func(pkg string)
+		reach = func(pkg string) {
+			fact, ok := facts[pkg]
+			if reached[pkg] || !ok {
+				return
+			}
+			reached[pkg] = true
+			for _, imported := range fact.ImportPaths() {
+				reach(imported)
+			}
+		}
+		reach(pkg)
+		merged.reaches[pkg] = reached
+	}
+	return merged
+}
+
+// DeclaredByImport checks if all ids are declared by the same imported package, a problem between them has been reported there already
+func (i Imports) DeclaredByImport(ids ...string) bool {
+	pkg := i.declaredIn[ids[0]]
+	for _, id := range ids {
+		if i.declaredIn[id] == "" || i.declaredIn[id] != pkg {
+			return false
+		}
+	}
+	return true
+}
+
+// ImportSpec returns the import bringing in the package which declares the given id
+func (i Imports) ImportSpec(pass *analysis.Pass, id string) ast.Node {
+	pkg, ok := i.declaredIn[id]
+	if !ok {
+		return nil
+	}
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err == nil && i.reaches[path][pkg] {
+				return spec
+			}
+		}
+	}
+	return nil
+}
+
+// FunctionName returns the name of a function qualified by the package name, e.g. "(*module.Module).Configure"
+func FunctionName(fn *types.Func) string {
+	if fn == nil {
+		return "an unknown function"
+	}
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		return fmt.Sprintf("(%s).%s", types.TypeString(recv.Type(), PackageName), fn.Name())
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+// PackageName qualifies types by the package name for messages
+func PackageName(pkg *types.Package) string {
+	return pkg.Name()
+}
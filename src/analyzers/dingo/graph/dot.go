@@ -0,0 +1,29 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The shapes of the node kinds and the styles of the edges in Graphviz
+var (
+	dotShapes = map[string]string{moduleNode: "component", typeNode: "box", functionNode: "ellipse"}
+	dotStyles = map[string]string{bindingEdge: "solid", injectionEdge: "dashed", dependsEdge: "dotted"}
+)
+
+// Writes the diagram as Graphviz DOT, e.g. to be rendered by `dot -Tsvg`
+func writeDOT(w io.Writer, d *diagram) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "digraph dingo {")
+	fmt.Fprintln(out, "\trankdir=LR;")
+	for _, n := range d.nodes {
+		fmt.Fprintf(out, "\t%s [label=%s, shape=%s];\n", strconv.Quote(n.id), strconv.Quote(n.label), dotShapes[n.kind])
+	}
+	for _, e := range d.edges {
+		fmt.Fprintf(out, "\t%s -> %s [label=%s, style=%s];\n", strconv.Quote(e.from), strconv.Quote(e.to), strconv.Quote(e.label), dotStyles[e.style])
+	}
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
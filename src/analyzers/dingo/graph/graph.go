@@ -0,0 +1,164 @@
+// Package graph writes the dingo wiring extracted by the bind checks as Graphviz DOT, Mermaid or JSON
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/bind"
+)
+
+// Formats are the supported output formats
+var Formats = []string{"dot", "mermaid", "json"}
+
+// The kinds of nodes, they are drawn with different shapes
+const (
+	moduleNode   = "module"
+	typeNode     = "type"
+	functionNode = "function"
+)
+
+type node struct {
+	id    string
+	label string
+	kind  string
+}
+
+// The styles of edges
+const (
+	bindingEdge   = "binding"
+	injectionEdge = "injection"
+	dependsEdge   = "depends"
+)
+
+type edge struct {
+	from  string
+	to    string
+	label string
+	style string
+}
+
+// diagram holds the nodes and edges of a graph in the order of their first appearance
+type diagram struct {
+	nodes []node
+	index map[string]int
+	edges []edge
+	known map[edge]bool
+}
+
+// Write writes the graph in the given format
+func Write(w io.Writer, graph *bind.Graph, format string) error {
+	switch format {
+	case "dot":
+		return writeDOT(w, newDiagram(graph))
+	case "mermaid":
+		return writeMermaid(w, newDiagram(graph))
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(graph)
+	}
+	return fmt.Errorf("unknown format %q, supported are %s", format, strings.Join(Formats, ", "))
+}
+
+// Creates the diagram of the graph:
+// - a module points to the types it binds, labeled with the kind of the binding, the annotation and the scope
+// - a bound type points to its target, i.e. the created type or the provider function
+// - a type points to the types injected into it
+// - a module points to the modules returned by its Depends() method
+func newDiagram(graph *bind.Graph) *diagram {
+	d := &diagram{index: make(map[string]int), known: make(map[edge]bool)}
+	modules := make(map[string]string)
+	for _, module := range graph.Modules {
+		modules[module.Type] = module.Name
+		d.addNode(module.Type, module.Name, moduleNode)
+	}
+	for _, module := range graph.Modules {
+		for _, depends := range module.Depends {
+			name, ok := modules[depends]
+			if !ok {
+				name = depends
+			}
+			d.addNode(depends, name, moduleNode)
+			d.addEdge(module.Type, depends, "depends", dependsEdge)
+		}
+	}
+
+	for _, binding := range graph.Bindings {
+		from := binding.Module
+		if name, ok := modules[from]; ok {
+			d.addNode(from, name, moduleNode)
+		} else {
+			from = binding.Function
+			d.addNode(from, binding.Function, functionNode)
+		}
+		d.addNode(binding.Type, binding.Name, typeNode)
+		d.addEdge(from, binding.Type, bindingLabel(binding), bindingEdge)
+		if binding.TargetKind == "" {
+			continue
+		}
+
+		// Targets without a name, e.g. function literals, are drawn per binding
+		target := binding.Target
+		if target == "" {
+			target = binding.TargetName + " " + binding.Location
+		}
+		kind := typeNode
+		if binding.TargetKind == "ToProvider" {
+			kind = functionNode
+		}
+		d.addNode(target, binding.TargetName, kind)
+		label := binding.TargetKind
+		if binding.Annotation != "" {
+			label += fmt.Sprintf(" @%s", binding.Annotation)
+		}
+		d.addEdge(binding.Type, target, label, bindingEdge)
+	}
+
+	for _, injection := range graph.Injections {
+		if injection.From == "" {
+			continue
+		}
+		d.addNode(injection.From, injection.FromName, typeNode)
+		d.addNode(injection.To, injection.ToName, typeNode)
+		d.addEdge(injection.From, injection.To, "injects "+injection.Collection, injectionEdge)
+	}
+	return d
+}
+
+// Describes a binding like "Bind @annotation (Singleton)"
+func bindingLabel(binding bind.GraphBinding) string {
+	label := binding.Kind
+	if binding.Annotation != "" {
+		label += fmt.Sprintf(" @%s", binding.Annotation)
+	}
+	if binding.MapKey != "" {
+		label += fmt.Sprintf(" [%s]", binding.MapKey)
+	}
+	if binding.Eager {
+		label += " (eager Singleton)"
+	} else if binding.Scope != "" {
+		label += fmt.Sprintf(" (%s)", binding.Scope)
+	}
+	return label
+}
+
+func (d *diagram) addNode(id string, label string, kind string) {
+	if _, ok := d.index[id]; ok {
+		return
+	}
+	d.index[id] = len(d.nodes)
+	d.nodes = append(d.nodes, node{id: id, label: label, kind: kind})
+}
+
+// Adds the edge once, the same binding might be declared by several calls
+func (d *diagram) addEdge(from string, to string, label string, style string) {
+	e := edge{from: from, to: to, label: strings.TrimSpace(label), style: style}
+	if d.known[e] {
+		return
+	}
+	d.known[e] = true
+	d.edges = append(d.edges, e)
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The shapes of the node kinds and the arrows of the edges in Mermaid
+var (
+	mermaidShapes = map[string][2]string{moduleNode: {"[[", "]]"}, typeNode: {"[", "]"}, functionNode: {"([", "])"}}
+	mermaidArrows = map[string]string{bindingEdge: "-->", injectionEdge: "-.->", dependsEdge: "==>"}
+)
+
+// Writes the diagram as Mermaid flowchart, e.g. to be embedded in markdown
+func writeMermaid(w io.Writer, d *diagram) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "flowchart LR")
+	// Mermaid ids can't contain the characters of type names, the nodes are numbered instead
+	for i, n := range d.nodes {
+		shape := mermaidShapes[n.kind]
+		fmt.Fprintf(out, "\tn%d%s\"%s\"%s\n", i, shape[0], mermaidText(n.label), shape[1])
+	}
+	for _, e := range d.edges {
+		fmt.Fprintf(out, "\tn%d %s|\"%s\"| n%d\n", d.index[e.from], mermaidArrows[e.style], mermaidText(e.label), d.index[e.to])
+	}
+	return out.Flush()
+}
+
+// Escapes the quotes of a text, the text is quoted so all other characters are taken literally
+func mermaidText(text string) string {
+	return strings.ReplaceAll(text, "\"", "#quot;")
+}
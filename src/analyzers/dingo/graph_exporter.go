@@ -0,0 +1,40 @@
+package dingo
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"flamingo.me/flamalyzer/src/analyzers/dingo/checks/bind"
+	"flamingo.me/flamalyzer/src/analyzers/dingo/graph"
+	"flamingo.me/flamalyzer/src/flamalyzer/driver"
+)
+
+// GraphExporter exports the dingo modules, bindings and injection points of the analysed packages by `graph dingo`
+type GraphExporter struct{}
+
+// Name of the graph in the subcommand
+func (e *GraphExporter) Name() string {
+	return "dingo"
+}
+
+// Export extracts the graph of every package matching the patterns, for main packages including the packages they import, and writes them as one graph.
+// The locations are written relative to the working directory.
+func (e *GraphExporter) Export(w io.Writer, format string, patterns []string) error {
+	results, err := driver.Run(nil, bind.GraphAnalyzer, patterns...)
+	if err != nil {
+		return err
+	}
+	merged := new(bind.Graph)
+	for _, result := range results {
+		packageGraph, ok := result.Value.(*bind.Graph)
+		if !ok {
+			return fmt.Errorf("no graph extracted for %s", result.Package.PkgPath)
+		}
+		merged.Merge(packageGraph)
+	}
+	if dir, err := os.Getwd(); err == nil {
+		merged.RelativeTo(dir)
+	}
+	return graph.Write(w, merged, format)
+}
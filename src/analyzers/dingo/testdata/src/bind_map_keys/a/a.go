@@ -0,0 +1,26 @@
+package a
+
+import (
+	"bind_map_keys/api"
+
+	"flamingo.me/dingo"
+)
+
+const invoice = "invoice"
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.BindMap(new(api.Payment), "paypal").To(new(api.PayPal))
+	injector.BindMap(new(api.Payment), invoice).To(new(api.PayPal))
+	injector.BindMap(new(api.Payment), "card").To(new(api.PayPal))
+	injector.BindMap(new(api.Payment), "card").To(new(api.PayPal)) // want `Duplicate Map Key! "api.Payment" is bound to the key "card" in \(\*a.Module\).Configure \(a.go:16\) and in \(\*a.Module\).Configure \(a.go:17\), the second Binding replaces the first one`
+
+	// The same key with another annotation or for another type is another entry
+	injector.BindMap(new(api.Payment), "card").AnnotatedWith("other").To(new(api.PayPal))
+	injector.BindMap(new(api.PayPal), "card").To(new(api.PayPal))
+
+	injector.BindMap(new(api.Payment), "").To(new(api.PayPal)) // want `Invalid Map Key! "api.Payment" is bound to an empty key`
+	key := "dynamic"
+	injector.BindMap(new(api.Payment), key).To(new(api.PayPal)) // want `Invalid Map Key! The key of "api.Payment" is not a constant string, duplicate keys can't be detected`
+}
@@ -0,0 +1,5 @@
+package api
+
+type Payment interface{}
+
+type PayPal struct{}
@@ -0,0 +1,17 @@
+// The modules of all imported packages come together in the main package of the application
+package main
+
+import (
+	"bind_map_keys/a"
+	"bind_map_keys/b" // want `Duplicate Map Key! "api.Payment" is bound to the key "paypal" in \(\*a.Module\).Configure \(a.go:14\) and in \(\*b.Module\).Configure \(b.go:17\)`
+	"bind_map_keys/c" // want `Duplicate Map Key! "api.Payment" is bound to the key "invoice" in \(\*a.Module\).Configure \(a.go:15\) and in \(\*c.Module\).Configure \(c.go:12\)`
+	"bind_map_keys/d"
+
+	"flamingo.me/dingo"
+)
+
+func main() {
+	dingo.NewInjector(new(a.Module), new(b.Module), new(c.Module))
+	// Module d binds the key "paypal" as well, but it is installed by another injector
+	dingo.NewInjector(new(d.Module))
+}
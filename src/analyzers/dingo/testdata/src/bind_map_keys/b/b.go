@@ -0,0 +1,18 @@
+package b
+
+import (
+	"bind_map_keys/a"
+	"bind_map_keys/api"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func (*Module) Depends() []dingo.Module {
+	return []dingo.Module{new(a.Module)}
+}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.BindMap(new(api.Payment), "paypal").To(new(api.PayPal))
+}
@@ -0,0 +1,13 @@
+package c
+
+import (
+	"bind_map_keys/api"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.BindMap(new(api.Payment), "invoice").To(new(api.PayPal))
+}
@@ -0,0 +1,13 @@
+package d
+
+import (
+	"bind_map_keys/api"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.BindMap(new(api.Payment), "paypal").To(new(api.PayPal))
+}
@@ -0,0 +1,60 @@
+package binding_helpers
+
+import (
+	di "flamingo.me/dingo"
+)
+
+type I interface{}
+
+type A struct{}
+
+type Module struct{}
+
+// Configure uses an aliased import of dingo
+func (*Module) Configure(injector *di.Injector) {
+	injector.Bind(new(I)).To(new(A))
+	bindHelper(injector)
+	(&binder{injector: injector}).bindAll()
+	register := func(i *di.Injector) {
+		i.BindMulti(new(I)).To(new(A))
+	}
+	register(injector)
+}
+
+// The bindings of a helper belong to the Configure method calling it
+func bindHelper(injector *di.Injector) {
+	injector.Bind(new(A)).AnnotatedWith("helper")
+	bindNested(&binder{injector: injector})
+}
+
+// The injector is passed on in a struct
+func bindNested(b *binder) {
+	b.injector.BindMap(new(I), "nested").To(new(A))
+}
+
+type binder struct {
+	injector *di.Injector
+}
+
+func (b *binder) bindAll() {
+	b.injector.Override(new(I), "").To(new(A))
+}
+
+// A helper no function of the package calls is collected on its own
+func Standalone(injector *di.Injector) {
+	injector.Bind(new(A)).AnnotatedWith("standalone")
+}
+
+// Function literals outside of functions holding an injector are collected on their own
+var modules = []func(*di.Injector){
+	func(injector *di.Injector) {
+		injector.Bind(new(A)).AnnotatedWith("literal")
+	},
+}
+
+func main() {
+	configure := func(injector *di.Injector) {
+		injector.Bind(new(A)).AnnotatedWith("main")
+	}
+	_ = configure
+}
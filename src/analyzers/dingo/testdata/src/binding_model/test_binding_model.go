@@ -0,0 +1,29 @@
+package binding_model
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface{}
+
+type A struct{}
+
+const annotation = "constant"
+
+func provideA() *A {
+	return new(A)
+}
+
+func (*A) Configure(injector *dingo.Injector, dynamic string) {
+	injector.Bind(new(I)).To(new(A))
+	injector.Bind(new(I)).AnnotatedWith("x").In(dingo.Singleton).ToInstance(new(A))
+	injector.BindMulti(new(I)).AnnotatedWith(annotation).ToProvider(provideA).AsEagerSingleton()
+	injector.BindMap(new(I), "key").In(dingo.ChildSingleton)
+	injector.Override(new(I), "y").AnnotatedWith(dynamic).To(new(A))
+
+	binding := injector.Bind(new(A))
+	if dynamic != "" {
+		binding.In(dingo.Singleton)
+	}
+	binding.ToProvider(provideA)
+}
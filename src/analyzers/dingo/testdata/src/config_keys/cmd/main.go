@@ -0,0 +1,15 @@
+package main
+
+import (
+	_ "config_keys/module" // want `Config Type Mismatch! "payment.enabled" is declared as bool but injected into field "Enabled" of "service.Config" \(service.go:5\) of type "string"` `Unknown Config Key! "payment.missing" is injected into field "Missing" of "service.Config" \(service.go:7\) but not declared by a CueConfig or in config/\*.yml` `Unknown Config Key! "core.auth.enabeld" is injected into field "Typo" in \(\*service.Service\).Inject \(service.go:16\)` `Config Type Mismatch! "core.auth.name" is declared as string but injected into field "Name" in \(\*service.Service\).Inject \(service.go:18\) of type "int"` `Config Type Mismatch! "core.auth.providers" is declared as list but injected into field "Provider"` `Unknown Config Key! "core.auth.secret"` `Unknown Config Key! "core.auth._hidden"`
+)
+
+type app struct{}
+
+func (a *app) Inject(cfg *struct {
+	Ratio float64 `inject:"config:app.ratio"`
+	Rate  float64 `inject:"config:app.rate"` // want `Unknown Config Key! "app.rate" is injected into field "Rate" in \(\*main.app\).Inject \(main.go:11\)`
+}) {
+}
+
+func main() {}
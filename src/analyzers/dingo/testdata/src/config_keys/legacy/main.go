@@ -0,0 +1,11 @@
+package main
+
+import (
+	_ "config_keys/legacymodule"
+)
+
+type app struct {
+	Enabled int `inject:"config:payment.enabled"` // want `Config Type Mismatch! "payment.enabled" is declared as bool`
+}
+
+func main() {}
@@ -0,0 +1,10 @@
+package legacymodule
+
+type Module struct {
+	Unknown string `inject:"config:legacy.unknown"`
+}
+
+// DefaultConfig can't be read, so unknown keys can't be reported
+func (m *Module) DefaultConfig() map[string]interface{} {
+	return map[string]interface{}{"legacy.unknown": "value"}
+}
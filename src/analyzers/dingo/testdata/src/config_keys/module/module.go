@@ -0,0 +1,29 @@
+package module
+
+import (
+	_ "config_keys/service"
+)
+
+type Module struct{}
+
+func (m *Module) CueConfig() string {
+	return `
+core: auth: {
+	enabled: bool | *true
+	name:    string | *"auth"
+	timeout: int & >=0 | *30
+	providers: [...string]
+	options: {...}
+	#Internal: {
+		secret: string
+	}
+	_hidden: string
+}
+app: {
+	// the greeting shown on the start page
+	greeting?: *"hello" | string
+	ratio:     float
+	nested:    strings.MinRunes(3)
+}
+`
+}
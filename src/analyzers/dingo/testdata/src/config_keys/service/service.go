@@ -0,0 +1,31 @@
+package service
+
+type Config struct {
+	Currency string  `inject:"config:shop.currency"`
+	Enabled  string  `inject:"config:payment.enabled"`
+	URL      bool    `inject:"config:payment.url"`
+	Missing  float64 `inject:"config:payment.missing,optional"`
+}
+
+type Service struct {
+	config *Config
+}
+
+func (s *Service) Inject(config *Config, cfg *struct {
+	Enabled   bool                   `inject:"config:core.auth.enabled"`
+	Typo      bool                   `inject:"config:core.auth.enabeld"`
+	Timeout   float64                `inject:"config:core.auth.timeout"`
+	Name      int                    `inject:"config:core.auth.name"`
+	Providers []string               `inject:"config:core.auth.providers"`
+	Provider  string                 `inject:"config:core.auth.providers"`
+	Option    string                 `inject:"config:core.auth.options.anything"`
+	Auth      map[string]interface{} `inject:"config:core.auth"`
+	Greeting  string                 `inject:"config:app.greeting"`
+	Nested    string                 `inject:"config:app.nested"`
+	Secret    string                 `inject:"config:core.auth.secret"`
+	Hidden    string                 `inject:"config:core.auth._hidden"`
+	Any       interface{}            `inject:"config:app.ratio"`
+	Annotated interface{}            `inject:"annotated"`
+}) {
+	s.config = config
+}
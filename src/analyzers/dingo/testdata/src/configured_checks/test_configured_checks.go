@@ -0,0 +1,27 @@
+package configured_checks
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface {
+	funA()
+}
+
+type A struct{}
+type B struct{}
+
+func (a *A) funA() {}
+
+// not reported as the pointer receiver check is disabled by the config
+func (b B) Inject() {
+}
+
+type C struct {
+	X string `inject:""` // want `Empty Inject-Tags are not allowed! Add more specific naming or use the Inject function for non configuration injections`
+}
+
+func (*C) Configure(injector *dingo.Injector) {
+	injector.Bind(new(I)).To(new(A))
+	injector.Bind(new(I)).AnnotatedWith("b").To(new(B)) // want "Incorrect Binding! \"\\*configured_checks.B\" must implement Interface \"\\*configured_checks.I\""
+}
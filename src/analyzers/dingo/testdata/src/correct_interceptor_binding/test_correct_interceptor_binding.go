@@ -0,0 +1,75 @@
+package correct_interceptor_binding
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface {
+	Do(name string) error
+	Name() string
+}
+
+type J interface {
+	Do(name string) error
+}
+
+type A struct{}
+
+type Interceptor struct {
+	I
+}
+
+func (i *Interceptor) Do(name string) error {
+	return i.I.Do(name)
+}
+
+type ValueInterceptor struct {
+	I
+	logger string
+}
+
+func (i ValueInterceptor) Name() string {
+	return i.I.Name()
+}
+
+type WrongEmbedding struct {
+	J
+}
+
+type NamedField struct {
+	next I
+}
+
+type SecondField struct {
+	logger string
+	I
+}
+
+type WrongSignature struct {
+	I
+}
+
+func (i *WrongSignature) Do(name string, force bool) error {
+	return nil
+}
+
+type Empty struct{}
+
+type NoStruct func()
+
+func configure(injector *dingo.Injector) {
+	injector.BindInterceptor(new(I), Interceptor{})
+	injector.BindInterceptor(new(I), ValueInterceptor{})
+	injector.BindInterceptor((*I)(nil), Interceptor{})
+
+	injector.BindInterceptor(I(nil), Interceptor{})    // want `Incorrect Interceptor! "correct_interceptor_binding.I" must be a pointer to an interface, e.g. new\(Interface\)`
+	injector.BindInterceptor(new(A), Interceptor{})    // want `Incorrect Interceptor! "\*correct_interceptor_binding.A" must be a pointer to an interface`
+	injector.BindInterceptor(new(I), new(Interceptor)) // want `Incorrect Interceptor! "\*correct_interceptor_binding.Interceptor" must be a struct value, Dingo creates a new instance for every intercepted "correct_interceptor_binding.I"`
+	injector.BindInterceptor(new(I), NoStruct(nil))    // want `Incorrect Interceptor! "correct_interceptor_binding.NoStruct" must be a struct embedding "correct_interceptor_binding.I"`
+	injector.BindInterceptor(new(I), Empty{})          // want `Incorrect Interceptor! "correct_interceptor_binding.Empty" must embed "correct_interceptor_binding.I" as its first field`
+	injector.BindInterceptor(new(I), WrongEmbedding{}) // want `Incorrect Interceptor! "correct_interceptor_binding.WrongEmbedding" must embed "correct_interceptor_binding.I" as its first field instead of "correct_interceptor_binding.J"`
+	injector.BindInterceptor(new(I), NamedField{})     // want `must embed "correct_interceptor_binding.I" as its first field instead of "correct_interceptor_binding.I"`
+	injector.BindInterceptor(new(I), SecondField{})    // want `must embed "correct_interceptor_binding.I" as its first field instead of "string"`
+	injector.BindInterceptor(new(J), Interceptor{})    // want `must embed "correct_interceptor_binding.J" as its first field instead of "correct_interceptor_binding.I"`
+	injector.BindInterceptor(new(I), WrongSignature{}) // want `Incorrect Interceptor! Method "Do" of "correct_interceptor_binding.WrongSignature" must have the Signature "func\(name string\) error" of "correct_interceptor_binding.I"`
+}
@@ -55,8 +55,9 @@ func (*C) Configure(injector *dingo.Injector, otherType *otherType) {
 	injector.Bind(new(I)).ToInstance(new(A))
 	injector.Bind(new(I)).ToInstance(new(B)) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
 
-	// check if string literals are allowed
-	injector.Bind(new(I)).ToInstance(A{})
+	// check struct literals, the value is kept by ToInstance so only a pointer implements the interface
+	injector.Bind(new(I)).ToInstance(A{}) // want "Value Binding! \"correct_interface_to_instance_binding.A\" is bound as value but only \"\\*correct_interface_to_instance_binding.A\" implements Interface \"\\*correct_interface_to_instance_binding.I\", bind a pointer instead"
+	injector.Bind(new(I)).ToInstance(&A{})
 	injector.Bind(new(I)).ToInstance(B{}) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
 
 	injector.BindMulti(new(I)).To(new(A))
@@ -83,6 +84,32 @@ func (*C) Configure(injector *dingo.Injector, otherType *otherType) {
 	injector.Bind((*I)(nil)).To(new(B)) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
 }
 
+// check binding chains, variables and nested blocks
+func (*C) chains(injector *dingo.Injector, enabled bool, names []string) {
+	injector.Bind(new(I)).AnnotatedWith("a").To(new(A))
+	injector.Bind(new(I)).AnnotatedWith("b").To(new(B))                                       // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+	injector.Bind(new(I)).In(dingo.Singleton).To(new(B))                                      // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+	injector.Bind(new(I)).To(new(B)).AsEagerSingleton()                                       // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+	injector.BindMap(new(I), "key").AnnotatedWith("c").In(dingo.Singleton).ToInstance(new(B)) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+
+	binding := injector.Bind(new(I))
+	binding.AnnotatedWith("d")
+	binding.To(new(B)) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+
+	var annotated = injector.Bind(new(I)).AnnotatedWith("e")
+	annotated.To(new(A))
+
+	if enabled {
+		injector.Bind(new(I)).To(new(B)) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+	}
+	for _, name := range names {
+		injector.Bind(new(I)).AnnotatedWith(name).To(new(B)) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+	}
+	func() {
+		injector.Bind(new(I)).To(new(B)) // want "Incorrect Binding! \"\\*correct_interface_to_instance_binding.B\" must implement Interface \"\\*correct_interface_to_instance_binding.I\""
+	}()
+}
+
 // check if it works in other functions than "configure"
 func (*D) functionName(injector *dingo.Injector, otherType *otherType) {
 	injector.Bind(new(I)).To(new(A))
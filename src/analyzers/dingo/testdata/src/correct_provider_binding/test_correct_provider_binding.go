@@ -0,0 +1,80 @@
+package correct_provider_binding
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface {
+	funA()
+}
+
+type A struct{}
+type B struct{}
+
+func (a *A) funA() {}
+
+type Dependency struct{}
+
+type DependencyProvider func() *Dependency
+
+type Mapper func(string) string
+
+type Path string
+
+type Event struct{}
+
+func provideA(dependency *Dependency) *A {
+	return new(A)
+}
+
+func provideB() *B {
+	return new(B)
+}
+
+func provideI() I {
+	return new(A)
+}
+
+func provideWithError(dependencyProvider DependencyProvider, mapper Mapper, multi []I, named map[string]I) (*A, error) {
+	return new(A), nil
+}
+
+func provideWithoutResult() {}
+
+func provideWithWrongSecondResult() (*A, bool) {
+	return new(A), true
+}
+
+func provideWithBoundValues(path Path, events chan Event) *A {
+	return new(A)
+}
+
+func provideWithUnnamedFunction(create func() *Dependency) *A {
+	return new(A)
+}
+
+func provideVariadic(dependencies ...*Dependency) *A {
+	return new(A)
+}
+
+func (*A) Configure(injector *dingo.Injector) {
+	injector.Bind(new(I)).ToProvider(provideA)
+	injector.Bind(new(I)).ToProvider(provideI)
+	injector.Bind(new(I)).ToProvider(provideWithError)
+	injector.BindMulti(new(I)).ToProvider(provideA)
+	injector.BindMap(new(I), "a").ToProvider(provideA)
+	injector.Bind(new(A)).ToProvider(provideA)
+	injector.Bind(new(A)).ToProvider(func() A { return A{} })
+	injector.Bind(new(Path)).ToInstance(Path("/tmp"))
+	injector.Bind(new(chan Event)).ToInstance(make(chan Event))
+	injector.Bind(new(I)).ToProvider(provideWithBoundValues)
+
+	injector.Bind(new(I)).ToProvider(provideB)                            // want "Incorrect Binding! Provider returns \"\\*correct_provider_binding.B\" which is not assignable to \"correct_provider_binding.I\""
+	injector.BindMulti(new(I)).ToProvider(func() *B { return nil })       // want "Incorrect Binding! Provider returns \"\\*correct_provider_binding.B\" which is not assignable to \"correct_provider_binding.I\""
+	injector.Bind(new(I)).ToProvider(new(A))                              // want "Incorrect Binding! Provider \"\\*correct_provider_binding.A\" must be a function"
+	injector.Bind(new(I)).ToProvider(provideWithoutResult)                // want "Incorrect Binding! Provider \"func\\(\\)\" must return a single value, optionally followed by an error"
+	injector.Bind(new(I)).ToProvider(provideWithWrongSecondResult)        // want "Incorrect Binding! Provider \"func\\(\\) \\(\\*correct_provider_binding.A, bool\\)\" must return a single value, optionally followed by an error"
+	injector.Bind(new(I)).ToProvider(provideWithUnnamedFunction)          // want "Incorrect Binding! Provider parameter \"create\" of type \"func\\(\\) \\*correct_provider_binding.Dependency\" is not injectable: functions must be named types with a `Provider` suffix to be injected as provider"
+	injector.Bind(new(I)).ToProvider(provideVariadic)                     // want "Incorrect Binding! Provider parameter \"dependencies\" of type \"\\[\\]\\*correct_provider_binding.Dependency\" is not injectable: variadic parameters can not be injected"
+	injector.Bind(new(I)).ToProvider(func(m map[int]I) *A { return nil }) // want "Provider parameter \"m\" of type \"map\\[int\\]correct_provider_binding.I\" is not injectable: maps must have string keys to be injected as map binding"
+}
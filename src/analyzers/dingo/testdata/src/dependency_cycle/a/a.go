@@ -0,0 +1,34 @@
+package a
+
+type I interface{}
+type J interface{}
+
+type A struct{}
+
+func (a *A) Inject(i I) {}
+
+// C and D inject each other
+type C struct{}
+
+func (c *C) Inject(d *D) {} // want `Dependency Cycle! "a.C" needs "a.D" by parameter "d" of \(\*a.C\).Inject \(a.go:13\), "a.D" needs "a.C" by field "C" of "a.D" \(a.go:16\), inject a Provider func\(\) T to break the cycle`
+
+type D struct {
+	C *C `inject:""`
+}
+
+// A Provider breaks the cycle
+type E struct{}
+
+func (e *E) Inject(f func() *F) {}
+
+type F struct {
+	E *E `inject:""`
+}
+
+type G struct{}
+
+func (g *G) Inject(g2 *G) {} // want `Dependency Cycle! "a.G" needs "a.G" by parameter "g2" of \(\*a.G\).Inject \(a.go:30\)`
+
+type H struct{}
+
+func (h *H) Inject(j J) {}
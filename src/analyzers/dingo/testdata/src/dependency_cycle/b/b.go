@@ -0,0 +1,9 @@
+package b
+
+import (
+	"dependency_cycle/a"
+)
+
+type B struct {
+	A *a.A `inject:""`
+}
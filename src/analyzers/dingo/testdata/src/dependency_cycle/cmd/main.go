@@ -0,0 +1,8 @@
+// The cycles across packages are reported in the main package of the application, which knows all dependencies
+package main
+
+import (
+	_ "dependency_cycle/module" // want `Dependency Cycle! "a.A" needs "a.I" by parameter "i" of \(\*a.A\).Inject \(a.go:8\), "a.I" needs "b.B" by the Binding in \(\*module.Module\).Configure \(module.go:13\), "b.B" needs "a.A" by field "A" of "b.B" \(b.go:8\)` `Dependency Cycle! "a.H" needs "a.J" by parameter "j" of \(\*a.H\).Inject \(a.go:34\), "a.J" needs "a.H" by parameter "h" of the Provider bound in \(\*module.Module\).Configure \(module.go:14\)`
+)
+
+func main() {}
@@ -0,0 +1,17 @@
+package module
+
+import (
+	"dependency_cycle/a"
+	"dependency_cycle/b"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(a.I)).To(new(b.B))
+	injector.Bind(new(a.J)).ToProvider(func(h *a.H) a.J {
+		return nil
+	})
+}
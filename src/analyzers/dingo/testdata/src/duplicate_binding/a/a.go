@@ -0,0 +1,22 @@
+package a
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface{}
+type J interface{}
+
+type A struct{}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(I)).To(new(A))
+	injector.Bind(new(J)).To(new(A))
+
+	// Multi- and Map-Bindings can be bound as often as needed
+	injector.BindMulti(new(I)).To(new(A))
+	injector.BindMulti(new(I)).To(new(A))
+	injector.BindMap(new(I), "a").To(new(A))
+}
@@ -0,0 +1,25 @@
+// The modules of all imported packages come together in the main package of the application
+package main
+
+import (
+	"duplicate_binding/a"
+	"duplicate_binding/b"
+	"duplicate_binding/c"
+	"duplicate_binding/d" // want `Duplicate Binding! "a.J" annotated with "c" is bound in \(\*c.Module\).Configure \(c.go:18\) and in \(\*d.Module\).Configure \(d.go:14\)`
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func main() {
+	dingo.NewInjector(new(a.Module), new(b.Module), new(c.Module), new(d.Module), new(Module))
+}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	// Module b binds this already
+	injector.Bind(new(a.I)).AnnotatedWith("b").To(new(a.A)) // want `Duplicate Binding! "a.I" annotated with "b" is bound in \(\*b.Module\).Configure \(b.go:23\) and in \(\*main.Module\).Configure \(app.go:21\)`
+
+	// The Override of module c replaces all other Bindings
+	injector.Bind(new(a.I)).To(new(a.A))
+}
@@ -0,0 +1,30 @@
+package b
+
+import (
+	"duplicate_binding/a"
+
+	"flamingo.me/dingo"
+)
+
+type B struct{}
+
+type Module struct{}
+
+const annotation = "b"
+
+func (*Module) Configure(injector *dingo.Injector) {
+	// Conflicts with the binding of module a
+	injector.Bind(new(a.I)).To(new(B))
+
+	// An equal Binding is accepted by dingo
+	injector.Bind(new(a.J)).To(new(a.A))
+
+	// Conflicts within the same module
+	injector.Bind(new(a.I)).AnnotatedWith(annotation).ToInstance(new(B))
+	injector.Bind(new(a.I)).AnnotatedWith("b").ToInstance(new(B)) // want `Duplicate Binding! "a.I" annotated with "b" is bound in \(\*b.Module\).Configure \(b.go:23\) and in \(\*b.Module\).Configure \(b.go:24\)`
+
+	// Annotations which are not constant can't be compared
+	injector.Bind(new(a.I)).AnnotatedWith(dynamic).To(new(B))
+}
+
+var dynamic = "dynamic"
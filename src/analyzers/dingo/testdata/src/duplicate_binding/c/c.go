@@ -0,0 +1,19 @@
+package c
+
+import (
+	"duplicate_binding/a"
+
+	"flamingo.me/dingo"
+)
+
+type C struct{}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	// Replaces the binding of module a, so it is no duplicate
+	injector.Override(new(a.I), "").To(new(C))
+
+	injector.Bind(new(a.I)).AnnotatedWith("c").To(new(C))
+	injector.Bind(new(a.J)).AnnotatedWith("c").To(new(C))
+}
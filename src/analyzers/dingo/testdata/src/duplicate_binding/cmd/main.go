@@ -0,0 +1,19 @@
+// Only the modules installed by the same injector conflict, without the Override of module c
+package main
+
+import (
+	"duplicate_binding/a"
+	"duplicate_binding/b" // want `Duplicate Binding! "a.I" is bound in \(\*a.Module\).Configure \(a.go:15\) and in \(\*b.Module\).Configure \(b.go:17\), use Override\(\) to replace a Binding`
+	"duplicate_binding/e"
+	"duplicate_binding/f" // want `Duplicate Binding! "a.J" is bound in \(\*a.Module\).Configure \(a.go:16\) and in \(\*f.Module\).Configure \(f.go:14\), use Override\(\) to replace a Binding`
+
+	"flamingo.me/dingo"
+)
+
+func main() {
+	dingo.NewInjector(new(a.Module), new(b.Module))
+	// Module e binds a.I as well, but it is installed by another injector
+	dingo.NewInjector(new(e.Module))
+	// Module a is installed by the Depends() method of module f
+	dingo.NewInjector(new(f.Module))
+}
@@ -0,0 +1,15 @@
+package d
+
+import (
+	"duplicate_binding/a"
+
+	"flamingo.me/dingo"
+)
+
+type D struct{}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(a.J)).AnnotatedWith("c").To(new(D))
+}
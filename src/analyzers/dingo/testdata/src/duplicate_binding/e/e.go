@@ -0,0 +1,15 @@
+package e
+
+import (
+	"duplicate_binding/a"
+
+	"flamingo.me/dingo"
+)
+
+type E struct{}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(a.I)).To(new(E))
+}
@@ -0,0 +1,19 @@
+package f
+
+import (
+	"duplicate_binding/a"
+
+	"flamingo.me/dingo"
+)
+
+type F struct{}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(a.J)).To(new(F))
+}
+
+func (*Module) Depends() []dingo.Module {
+	return []dingo.Module{new(a.Module)}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"generics/store"
+
+	"flamingo.me/dingo"
+)
+
+type Invoice struct{}
+
+type Handler struct {
+	products *store.Service[store.Product]
+	invoices *store.Service[Invoice]
+	orders   store.Repository[store.Order]
+}
+
+// The dependencies of the generic service are known for the instances, the repository of invoices is not bound
+func (h *Handler) Inject(products *store.Service[store.Product], invoices *store.Service[Invoice], orders store.Repository[store.Order]) { // want `Unbound Interface! "store.Repository\[main.Invoice\]" is injected as parameter "repository" of \(\*store.Service\[main.Invoice\]\).Inject \(app.go:18\) but not bound in any module`
+	h.products = products
+	h.invoices = invoices
+	h.orders = orders
+}
+
+func (h *Handler) Handle() {
+	_, _ = h.products.Get("1")
+	_, _ = h.invoices.Get("1")
+	_, _ = h.orders.Find("1")
+}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(Handler))
+}
+
+// The repositories are bound by the store module
+func (*Module) Depends() []dingo.Module {
+	return []dingo.Module{new(store.Module)}
+}
+
+func main() {
+	dingo.NewInjector(new(Module))
+}
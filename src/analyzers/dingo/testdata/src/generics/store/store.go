@@ -0,0 +1,74 @@
+package store
+
+import (
+	"flamingo.me/dingo"
+)
+
+type Product struct{}
+
+type Order struct{}
+
+type DB struct{}
+
+type Repository[T any] interface {
+	Find(id string) (T, error)
+}
+
+type sqlRepository[T any] struct {
+	db *DB
+}
+
+func (r *sqlRepository[T]) Inject(db *DB) {
+	r.db = db
+}
+
+func (r *sqlRepository[T]) Find(id string) (T, error) {
+	var found T
+	_ = r.db
+	return found, nil
+}
+
+type brokenRepository[T any] struct{}
+
+func newRepository[T any](db *DB) Repository[T] {
+	return &sqlRepository[T]{db: db}
+}
+
+// Service gets the repository of its type injected, the dependency is known only for the instances
+type Service[T any] struct {
+	repository Repository[T]
+}
+
+func (s *Service[T]) Inject(repository Repository[T]) {
+	s.repository = repository
+}
+
+func (s *Service[T]) Get(id string) (T, error) {
+	return s.repository.Find(id)
+}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	bindRepository[Product](injector)
+	bindRepository[Order](injector)
+
+	injector.Bind(new(Repository[Order])).AnnotatedWith("provided").ToProvider(newRepository[Order])
+	// The annotations differ from those of the helper, which binds "broken" for every instance
+	injector.Bind(new(Repository[Product])).AnnotatedWith("brokenProduct").To(new(brokenRepository[Product])) // want `Incorrect Binding! "\*generics/store.brokenRepository\[generics/store.Product\]" must implement Interface "\*generics/store.Repository\[generics/store.Product\]"`
+	injector.Bind(new(Repository[Order])).AnnotatedWith("mixed").To(new(sqlRepository[Product]))              // want `Incorrect Binding! "\*generics/store.sqlRepository\[generics/store.Product\]" must implement Interface "\*generics/store.Repository\[generics/store.Order\]"`
+	injector.Bind(new(Repository[Order])).AnnotatedWith("mixedProvider").ToProvider(newRepository[Product])   // want `Incorrect Binding! Provider returns "generics/store.Repository\[generics/store.Product\]" which is not assignable to "generics/store.Repository\[generics/store.Order\]"`
+}
+
+// The bindings of the helper are checked with the type parameter and bound with the type arguments of each call
+func bindRepository[T any](injector *dingo.Injector) {
+	injector.Bind(new(Repository[T])).To(new(sqlRepository[T]))
+	injector.Bind(new(Repository[T])).AnnotatedWith("provider").ToProvider(func(db *DB) Repository[T] {
+		return &sqlRepository[T]{db: db}
+	})
+	injector.Bind(new(Repository[T])).AnnotatedWith("broken").To(new(brokenRepository[T])) // want `Incorrect Binding! "\*generics/store.brokenRepository\[T\]" must implement Interface "\*generics/store.Repository\[T\]"`
+
+	// a type parameter itself is known only for the instances
+	injector.Bind(new(T)).To(new(sqlRepository[T]))
+	injector.Bind(new(Service[T])).To(new(T))
+}
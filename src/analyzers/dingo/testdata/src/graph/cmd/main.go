@@ -0,0 +1,11 @@
+package main
+
+import (
+	"flamingo.me/dingo"
+
+	"graph/module"
+)
+
+func main() {
+	_, _ = dingo.NewInjector(new(module.Module))
+}
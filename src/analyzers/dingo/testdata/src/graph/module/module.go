@@ -0,0 +1,35 @@
+package module
+
+import (
+	"flamingo.me/dingo"
+
+	"graph/service"
+)
+
+type LoggerModule struct{}
+
+func (*LoggerModule) Configure(injector *dingo.Injector) {
+	injector.Bind(new(service.Logger)).ToInstance(new(logger))
+}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(service.Repository)).ToProvider(service.NewRepository).In(dingo.Singleton)
+	injector.Bind(new(service.Repository)).AnnotatedWith("cached").ToProvider(func() service.Repository { return nil })
+	injector.BindMulti(new(service.Handler)).To(new(handler))
+	injector.BindMap(new(service.Handler), "default").To(new(handler)).AsEagerSingleton()
+	injector.Bind(new(service.Service))
+}
+
+func (*Module) Depends() []dingo.Module {
+	return []dingo.Module{new(LoggerModule)}
+}
+
+type handler struct{}
+
+func (*handler) Handle() {}
+
+type logger struct{}
+
+func (*logger) Log(message string) {}
@@ -0,0 +1,37 @@
+package service
+
+type Repository interface {
+	Find() string
+}
+
+type Handler interface {
+	Handle()
+}
+
+type Service struct {
+	repository Repository
+	handlers   []Handler
+	Debug      bool   `inject:"config:graph.debug"`
+	Logger     Logger `inject:""`
+}
+
+type Logger interface {
+	Log(message string)
+}
+
+func (s *Service) Inject(repository Repository, handlers []Handler) *Service {
+	s.repository = repository
+	s.handlers = handlers
+	return s
+}
+
+type repository struct{}
+
+func (*repository) Find() string {
+	return ""
+}
+
+// NewRepository is the provider of the Repository
+func NewRepository(logger Logger) Repository {
+	return new(repository)
+}
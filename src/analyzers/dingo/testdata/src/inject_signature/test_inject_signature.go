@@ -0,0 +1,75 @@
+package inject_signature
+
+import (
+	"fmt"
+)
+
+type I interface{}
+
+type Dependency struct{}
+
+type Service struct {
+	dependency *Dependency
+	i          I
+	name       string
+	config     struct {
+		timeout float64
+	}
+}
+
+func (s *Service) Inject(dependency *Dependency, i I, cfg *struct {
+	Name    string  `inject:"config:name"`
+	Timeout float64 `inject:"config:timeout,optional"`
+}) *Service {
+	s.dependency = dependency
+	s.i = i
+	if cfg != nil {
+		s.name = cfg.Name
+		s.config.timeout = float64(cfg.Timeout)
+	}
+	return s
+}
+
+type Returning struct{}
+
+func (r *Returning) Inject() error { // want `Incorrect Inject Signature! The Inject method must not return anything else than its receiver "\*inject_signature.Returning"`
+	return nil
+}
+
+type Other struct{}
+
+func (o *Other) Inject() *Service { // want `must not return anything else than its receiver`
+	return nil
+}
+
+type ByValue struct {
+	dependency Dependency
+}
+
+func (b *ByValue) Inject(dependency Dependency, unused *Dependency, _ I) { // want `Incorrect Inject Signature! The struct "inject_signature.Dependency" is injected by value, use a pointer or an interface instead` `Incorrect Inject Signature! The parameter "unused" is never used, remove it or assign it to a field` `The parameter "_" is never used`
+	b.dependency = dependency
+}
+
+type Unnamed struct{}
+
+func (u *Unnamed) Inject(*Service) { // want `The parameter "\*inject_signature.Service" is never used`
+}
+
+type Logic struct {
+	name string
+	i    I
+}
+
+func (l *Logic) Inject(i I, cfg *struct {
+	Name string `inject:"config:name"`
+}) {
+	l.i = i
+	name := cfg.Name          // want `Incorrect Inject Signature! The Inject method should only assign its parameters to fields, move the logic to the methods using them`
+	l.name = fmt.Sprint(name) // want `should only assign its parameters to fields`
+	if cfg.Name == "" {       // want `should only assign its parameters to fields`
+		l.name = "default"
+	}
+	if i != nil {
+		fmt.Println(i) // want `should only assign its parameters to fields`
+	}
+}
@@ -0,0 +1,22 @@
+package inject_signature_logic
+
+import (
+	"fmt"
+)
+
+type I interface{}
+
+type Logic struct {
+	name string
+	i    I
+}
+
+func (l *Logic) Inject(i I, unused I, cfg *struct { // want `The parameter "unused" is never used`
+	Name string `inject:"config:name"`
+}) {
+	l.i = i
+	if cfg.Name == "" {
+		l.name = "default"
+	}
+	l.name = fmt.Sprint(cfg.Name)
+}
@@ -0,0 +1,4 @@
+package api
+
+type Service interface{}
+type Logger interface{}
@@ -0,0 +1,12 @@
+// The modules of all imported packages come together in the main package of the application
+package main
+
+import (
+	_ "module_dependencies/auth"
+	_ "module_dependencies/cart" // want `Missing Module Dependency! "cart.Module" consumes "api.Service" injected as parameter "auth" of \(\*application.Service\).Inject \(service.go:9\), which is bound by "auth.Module" in \(\*auth.Module\).Configure \(auth.go:14\), add it to Depends\(\)`
+	_ "module_dependencies/checkout"
+	_ "module_dependencies/dynamic"
+	_ "module_dependencies/wrong" // want `Missing Module Dependency! "wrong.Module" consumes "api.Service" injected as field "Auth" of "wrong.Service" \(wrong.go:12\), which is bound by "auth.Module" in \(\*auth.Module\).Configure \(auth.go:14\), add it to Depends\(\)`
+)
+
+func main() {}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"module_dependencies/api"
+
+	"flamingo.me/dingo"
+)
+
+type service struct{}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(api.Service)).To(new(service))
+}
@@ -0,0 +1,9 @@
+package application
+
+import (
+	"module_dependencies/api"
+)
+
+type Service struct{}
+
+func (s *Service) Inject(auth api.Service) {}
@@ -0,0 +1,18 @@
+package cart
+
+import (
+	"module_dependencies/cart/application"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+// The auth module is missing, it is reported in the package knowing all modules
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(application.Service)).In(dingo.Singleton)
+}
+
+func (*Module) Depends() []dingo.Module {
+	return nil
+}
@@ -0,0 +1,22 @@
+package checkout
+
+import (
+	"module_dependencies/api"
+	"module_dependencies/auth"
+
+	"flamingo.me/dingo"
+)
+
+type Service struct{}
+
+func (s *Service) Inject(auth api.Service) {}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {}
+
+func (*Module) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(auth.Module),
+	}
+}
@@ -0,0 +1,16 @@
+package core
+
+import (
+	"module_dependencies/api"
+
+	"flamingo.me/dingo"
+)
+
+type logger struct{}
+
+// The unexported module is added by the application, it can't be listed in Depends()
+type module struct{}
+
+func (*module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(api.Logger)).To(new(logger))
+}
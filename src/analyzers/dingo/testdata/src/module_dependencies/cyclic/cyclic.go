@@ -0,0 +1,23 @@
+package cyclic
+
+import (
+	"flamingo.me/dingo"
+)
+
+type X struct{}
+
+func (*X) Configure(injector *dingo.Injector) {}
+
+func (*X) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(Y), // want `Module Cycle! "cyclic.X" depends on "cyclic.Y" \(cyclic.go:13\), "cyclic.Y" depends on "cyclic.X" \(cyclic.go:22\)`
+	}
+}
+
+type Y struct{}
+
+func (*Y) Configure(injector *dingo.Injector) {}
+
+func (*Y) Depends() []dingo.Module {
+	return []dingo.Module{&X{}}
+}
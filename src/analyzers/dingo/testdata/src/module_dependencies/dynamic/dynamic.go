@@ -0,0 +1,22 @@
+package dynamic
+
+import (
+	"module_dependencies/api"
+
+	"flamingo.me/dingo"
+)
+
+var modules []dingo.Module
+
+type Service struct{}
+
+func (s *Service) Inject(auth api.Service) {}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {}
+
+// The dependencies can't be determined
+func (*Module) Depends() []dingo.Module {
+	return modules
+}
@@ -0,0 +1,18 @@
+package wrong
+
+import (
+	"module_dependencies/api"
+	_ "module_dependencies/auth"
+	_ "module_dependencies/core"
+
+	"flamingo.me/dingo"
+)
+
+type Service struct {
+	Auth   api.Service `inject:""`
+	Logger api.Logger  `inject:""`
+}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {}
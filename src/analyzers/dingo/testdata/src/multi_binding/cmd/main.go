@@ -0,0 +1,13 @@
+package main
+
+import (
+	"multi_binding/domain"
+	_ "multi_binding/module" // want `Missing Map Binding! map\[string\]domain.Single is injected as field "Unbound" of "service.Service" \(service.go:9\) but "domain.Single" is not bound by BindMap\(\), the map is always empty` `Missing Multi Binding! \[\]domain.Unbound is injected as parameter "missing" of \(\*service.Service\).Inject \(service.go:12\) but "domain.Unbound" is not bound by BindMulti\(\), the slice is always empty` `Missing Map Binding! map\[string\]domain.Handler is injected as parameter "missingMap"` `Missing Single Binding! "domain.Single" is injected as parameter "single" of \(\*service.Service\).Inject \(service.go:12\) but only bound by BindMulti\(\), inject \[\]domain.Single instead` `Missing Single Binding! "domain.Mapped" is injected as parameter "mappedSingle" of \(\*service.Service\).Inject \(service.go:12\) but only bound by BindMap\(\), inject map\[string\]domain.Mapped instead`
+)
+
+type app struct{}
+
+func (a *app) Inject(providers []domain.HandlerProvider, unbound []domain.Unbound) { // want `Missing Multi Binding! \[\]domain.Unbound is injected as parameter "unbound" of \(\*main.app\).Inject \(main.go:10\)`
+}
+
+func main() {}
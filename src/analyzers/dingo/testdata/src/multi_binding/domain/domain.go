@@ -0,0 +1,11 @@
+package domain
+
+type Handler interface{}
+
+type HandlerProvider func() Handler
+
+type Mapped interface{}
+
+type Single interface{}
+
+type Unbound interface{}
@@ -0,0 +1,17 @@
+package module
+
+import (
+	"multi_binding/domain"
+	"multi_binding/service"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(service.Service)).To(new(service.Service))
+	injector.BindMulti(new(domain.Handler)).ToInstance(nil)
+	injector.BindMulti(new(domain.Single)).ToInstance(nil)
+	injector.BindMap(new(domain.Mapped), "mapped").ToInstance(nil)
+}
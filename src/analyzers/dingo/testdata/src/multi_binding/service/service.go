@@ -0,0 +1,13 @@
+package service
+
+import (
+	"multi_binding/domain"
+)
+
+type Service struct {
+	Handlers []domain.Handler         `inject:""`
+	Unbound  map[string]domain.Single `inject:""`
+}
+
+func (s *Service) Inject(handlers []domain.Handler, providers []domain.HandlerProvider, mapped map[string]domain.Mapped, missing []domain.Unbound, missingMap map[string]domain.Handler, single domain.Single, mappedSingle domain.Mapped, indexed map[int]domain.Handler) {
+}
@@ -0,0 +1,88 @@
+// Package odd_bindings is a corpus of unusual binding expressions, all checks must handle them without crashing
+package odd_bindings
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface {
+	funA()
+}
+
+type A struct{}
+
+func (a *A) funA() {}
+
+type B struct{}
+
+type Interceptor struct {
+	I
+}
+
+func newI() *I {
+	return new(I)
+}
+
+func newA() *A {
+	return new(A)
+}
+
+func provideA() *A {
+	return new(A)
+}
+
+type Module struct{}
+
+// the types passed as interface are only known at runtime, these bindings are skipped
+func (*Module) bindAny(injector *dingo.Injector, what interface{}, to interface{}, i I) {
+	injector.Bind(what).To(new(A))
+	injector.Bind(what).ToInstance(to)
+	injector.Bind(new(I)).AnnotatedWith("to").To(to)
+	injector.Bind(new(I)).AnnotatedWith("toInstance").ToInstance(to)
+	injector.Bind(new(I)).AnnotatedWith("instance").ToInstance(i)
+	injector.Bind(new(I)).AnnotatedWith("provider").ToProvider(to)
+	injector.BindInterceptor(what, to)
+	injector.Override(what, "").To(to)
+}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	// bound types which are no new() expressions, the annotations keep the bindings of I apart
+	injector.Bind(newI()).AnnotatedWith("a").To(new(A))
+	injector.Bind(newI()).AnnotatedWith("b").To(new(B)) // want `Incorrect Binding! "\*odd_bindings.B" must implement Interface "\*odd_bindings.I"`
+	injector.Bind((*I)(nil)).AnnotatedWith("conversion").ToInstance(newA())
+	injector.Bind(A{}).ToInstance(A{})
+	injector.Bind(A{}).AnnotatedWith("b").To(new(B)) // want `Incorrect Binding! "\*odd_bindings.B" must be assignable to "odd_bindings.A"`
+	injector.Bind(new(*I)).To(new(A))                // want `Incorrect Binding! "\*odd_bindings.A" must be assignable to "\*\*odd_bindings.I"`
+
+	// bound types which are not named
+	injector.Bind(new(func())).ToInstance(func() {})
+	injector.Bind(new(func())).AnnotatedWith("a").ToInstance(newA) // want `Incorrect Binding! "func\(\) \*odd_bindings.A" must have Signature of "func\(\)"`
+	injector.Bind(new(map[string]int)).ToInstance(map[string]int{})
+	injector.Bind(new([]I)).ToInstance([]I{})
+	injector.Bind(new(chan int)).ToInstance(make(chan int))
+	injector.Bind(new(int)).ToInstance(1)
+	injector.Bind(new(float64)).ToInstance(1) // want `Incorrect Binding! "int" must be assignable to "\*float64"`
+
+	// targets which are no new() expressions
+	injector.Bind(new(I)).AnnotatedWith("newA").ToInstance(newA())
+	injector.Bind(new(I)).AnnotatedWith("converted").ToInstance(I(newA()))
+	injector.Bind(new(I)).To(newI())                                   // want `Dependency Cycle! "odd_bindings.I" needs "odd_bindings.I"`
+	injector.Bind(new(I)).AnnotatedWith("function").To(provideA)       // want `Incorrect Binding! "func\(\) \*odd_bindings.A" must implement Interface "\*odd_bindings.I"`
+	injector.Bind(new(I)).AnnotatedWith("nil").To(nil)                 // want `Nil Binding! To\(nil\) has no type, Dingo panics when binding it to "\*odd_bindings.I"`
+	injector.Bind(new(I)).AnnotatedWith("nilProvider").ToProvider(nil) // want `Incorrect Binding! Provider "untyped nil" must be a function`
+
+	// bindings which are not finished
+	injector.Bind(new(B))
+	injector.Bind(new(I)).AnnotatedWith("unfinished")
+	_ = injector.Bind(new(B))
+
+	// parentheses and bindings called through a function value, the latter can't be resolved and are logged in debug mode
+	(injector.Bind(new(I))).AnnotatedWith("outer").To(new(B))    // want `Incorrect Binding! "\*odd_bindings.B" must implement Interface`
+	(injector).Bind(new(I)).AnnotatedWith("parens").To((new(B))) // want `Incorrect Binding! "\*odd_bindings.B" must implement Interface`
+	bind := injector.Bind
+	bind(new(I)).To(new(B))
+
+	// interceptors which are no new() expressions
+	injector.BindInterceptor(newI(), Interceptor{})
+	injector.BindInterceptor(nil, Interceptor{}) // want `Incorrect Interceptor! "untyped nil" must be a pointer to an interface`
+}
@@ -0,0 +1,27 @@
+package a
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface {
+	Do()
+}
+
+type Plugin interface {
+	Run()
+}
+
+type Unbound interface{}
+
+type A struct{}
+
+func (*A) Do() {}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(I)).To(new(A))
+	injector.Bind(new(I)).AnnotatedWith("x").To(new(A))
+	injector.BindMulti(new(Plugin)).To(new(A))
+}
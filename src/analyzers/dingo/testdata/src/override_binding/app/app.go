@@ -0,0 +1,18 @@
+package main
+
+import (
+	_ "override_binding/a"
+	_ "override_binding/b" // want `Override of Multi Binding! "a.Plugin" is overridden in \(\*b.Module\).Configure \(b.go:28\) but only bound by BindMulti\(\), which Override\(\) doesn't replace` `Missing Original Binding! "a.I" annotated with "y" is overridden in \(\*b.Module\).Configure \(b.go:31\) but only bound without annotation and with the annotation "x"` `Missing Original Binding! "a.Unbound" is overridden in \(\*b.Module\).Configure \(b.go:34\) but not bound in any module, use Bind\(\) instead`
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+type Local struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Override(new(Local), "") // want `Missing Original Binding! "main.Local" is overridden in \(\*main.Module\).Configure \(app.go:15\) but not bound in any module, use Bind\(\) instead`
+}
+
+func main() {}
@@ -0,0 +1,38 @@
+package b
+
+import (
+	"override_binding/a"
+
+	"flamingo.me/dingo"
+)
+
+type B struct{}
+
+func (*B) Do() {}
+
+func (*B) Run() {}
+
+type C struct{}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	// Replaces the binding of module a
+	injector.Override(new(a.I), "").To(new(B))
+	injector.Override(new(a.I), "x").To(new(B))
+
+	// The target is checked like the target of a binding
+	injector.Override(new(a.I), "").To(new(C)) // want `Incorrect Override! "\*override_binding/b.C" must implement Interface "\*override_binding/a.I"`
+
+	// Override doesn't replace multi bindings
+	injector.Override(new(a.Plugin), "").To(new(B))
+
+	// The annotation doesn't match an existing binding
+	injector.Override(new(a.I), "y").To(new(B))
+
+	// There is nothing to replace
+	injector.Override(new(a.Unbound), "").To(new(B))
+
+	// A value is kept by ToInstance, only the pointer implements the interface
+	injector.Override(new(a.I), "").ToInstance(B{}) // want `Value Override! "override_binding/b.B" is bound as value but only "\*override_binding/b.B" implements Interface "\*override_binding/a.I", bind a pointer instead`
+}
@@ -7,3 +7,18 @@ func (a A) Inject() { // want `Missing pointer in function receiver. Inject meth
 }
 func (b *B) Inject() { // no Error
 }
+
+type C struct{}
+
+func (C) Inject() { // want `Missing pointer in function receiver. Inject method must have a pointer receiver!`
+}
+
+type Repository[T any] struct{}
+
+func (r Repository[T]) Inject() { // want `Missing pointer in function receiver. Inject method must have a pointer receiver!`
+}
+
+type Cache[K comparable, V any] struct{}
+
+func (c *Cache[K, V]) Inject() { // no Error
+}
@@ -0,0 +1,6 @@
+package proper_inject_tags
+
+type otherFileCfg struct {
+	// this is allowed because it is used as argument type in an inject function of another file
+	Debug bool `inject:"config:debug"`
+}
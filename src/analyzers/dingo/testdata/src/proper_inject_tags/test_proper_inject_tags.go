@@ -32,13 +32,36 @@ type Z struct {
 	X bool `inject:""` // want `Empty Inject-Tags are not allowed! Add more specific naming or use the Inject function for non configuration injections`
 }
 
+type embeddedCfg struct {
+	// this is allowed because the struct is embedded in a struct used as argument type in an inject function
+	Timeout float64 `inject:"config:timeout"`
+}
+
+type embeddingCfg struct {
+	embeddedCfg
+	Name string `json:"name" inject:"config:name"` // other tags are allowed beside the inject tag
+}
+
+type aliasedCfg = specialInjectCfg
+
+type E struct {
+	X bool `json:"x" inject:"config:x"` // want `Injections should be referenced in the Inject function!`
+	Y bool "inject:\"\""                // want `Empty Inject-Tags are not allowed!`
+	Z bool `json:"z"`
+}
+
 func (d *D) Inject(
 	service *A,
 	z *Z,
 	cfg *specialInjectCfg,
 	annotated *struct {
 		Mapper Mapper `inject:"my-annotations,optional"` // this is allowed as it is an argument of the Inject function
+		Empty  Mapper `inject:",optional"`               // want `Empty Inject-Tags are not allowed!`
+		Typo   Mapper `inject:"my-annotations,optinal"`  // want `Unknown Inject-Tag option "optinal"! Only "optional" is supported`
 	},
+	embedding embeddingCfg,
+	aliased *aliasedCfg,
+	other *otherFileCfg,
 ) *D {
 
 	d.servie = service
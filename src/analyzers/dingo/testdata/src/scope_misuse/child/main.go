@@ -0,0 +1,12 @@
+package main
+
+import (
+	"scope_misuse/module"
+
+	"flamingo.me/dingo"
+)
+
+func main() {
+	injector, _ := dingo.NewInjector(new(module.Module))
+	injector.Child()
+}
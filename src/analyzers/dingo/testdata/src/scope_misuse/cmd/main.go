@@ -0,0 +1,16 @@
+package main
+
+import (
+	_ "scope_misuse/module" // want `Scope Misuse! "service.Stateless" is bound in ChildSingleton scope in \(\*module.Module\).Configure \(module.go:29\)` `Scope Misuse! "service.Connection" is bound in ChildSingleton scope in \(\*module.Module\).Configure \(module.go:42\) but no child injector is created, use dingo.Singleton`
+	"scope_misuse/web"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(web.Responder)).In(dingo.ChildSingleton) // want `Scope Misuse! "web.Responder" is bound in ChildSingleton scope in \(\*main.Module\).Configure \(main.go:13\) but no child injector is created`
+}
+
+func main() {}
@@ -0,0 +1,60 @@
+package module
+
+import (
+	"scope_misuse/service"
+	"scope_misuse/web"
+
+	"flamingo.me/dingo"
+)
+
+type Cache struct{}
+
+type Module struct{}
+
+// Opens a connection on every call
+//
+//flamalyzer:expensive
+func provideCache() *Cache {
+	return new(Cache)
+}
+
+func provideConnection() *service.Connection {
+	return new(service.Connection)
+}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(service.Stateless)).In(dingo.Singleton)
+	injector.Bind(new(service.RequestField)).In(dingo.Singleton)                              // want `Scope Misuse! "service.RequestField" is bound in Singleton scope but holds the request-scoped "web.Request" by field "request" of "service.RequestField", don't bind it as singleton`
+	injector.Bind(new(service.Stateless)).To(new(service.RequestInjected)).AsEagerSingleton() // want `Scope Misuse! "service.Stateless" is bound in Singleton scope but holds the request-scoped "web.Request" by parameter "request" of \(\*service.RequestInjected\).Inject`
+	injector.Bind(new(service.Stateless)).AnnotatedWith("provided").ToProvider(func(request *web.Request) *service.Stateless {
+		return new(service.Stateless)
+	}).In(dingo.ChildSingleton) // want `Scope Misuse! "service.Stateless" is bound in ChildSingleton scope but holds the request-scoped "web.Request" by parameter "request" of the Provider`
+
+	// Without a scope a new instance is created for every injection
+	injector.Bind(new(service.RequestField))
+	injector.Bind(new(service.RequestInjected)).ToInstance(new(service.RequestInjected)).In(dingo.Singleton)
+
+	injector.Bind(new(Cache)).ToProvider(provideCache).AsEagerSingleton() // want `Scope Misuse! The Provider module.provideCache is marked as expensive but bound with AsEagerSingleton\(\) in \(\*module.Module\).Configure, it is called when the injector is created`
+	injector.Bind(new(Cache)).AnnotatedWith("lazy").ToProvider(provideCache).In(dingo.Singleton)
+	injector.Bind(new(service.Connection)).ToProvider(service.Connect).AsEagerSingleton() // want `The Provider service.Connect is marked as expensive`
+	injector.Bind(new(service.Connection)).AnnotatedWith("cheap").ToProvider(provideConnection).AsEagerSingleton()
+
+	injector.Bind(new(service.Connection)).AnnotatedWith("child").In(dingo.ChildSingleton)
+}
+
+// The helper is called by two modules, its binding is reported once
+func bindRequestField(injector *dingo.Injector) {
+	injector.Bind(new(service.RequestField)).AnnotatedWith("helper").In(dingo.Singleton) // want `Scope Misuse! "service.RequestField" is bound in Singleton scope but holds the request-scoped "web.Request"`
+}
+
+type FirstHelperModule struct{}
+
+func (*FirstHelperModule) Configure(injector *dingo.Injector) {
+	bindRequestField(injector)
+}
+
+type SecondHelperModule struct{}
+
+func (*SecondHelperModule) Configure(injector *dingo.Injector) {
+	bindRequestField(injector)
+}
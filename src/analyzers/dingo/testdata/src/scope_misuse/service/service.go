@@ -0,0 +1,24 @@
+package service
+
+import (
+	"scope_misuse/web"
+)
+
+type Stateless struct {
+	responder *web.Responder
+}
+
+type RequestField struct {
+	request *web.Request
+}
+
+type RequestInjected struct{}
+
+func (s *RequestInjected) Inject(responder *web.Responder, request *web.Request) {}
+
+type Connection struct{}
+
+//flamalyzer:expensive
+func Connect() *Connection {
+	return new(Connection)
+}
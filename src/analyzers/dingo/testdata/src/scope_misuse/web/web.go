@@ -0,0 +1,5 @@
+package web
+
+type Request struct{}
+
+type Responder struct{}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"flamingo.me/dingo"
+)
+
+type Repository interface {
+	Find() string
+}
+
+type repository struct{}
+
+func (*repository) Find() string {
+	return ""
+}
+
+type Service struct {
+	injector *dingo.Injector
+}
+
+func (s *Service) Inject(injector *dingo.Injector) *Service {
+	s.injector = injector
+	return s
+}
+
+// Using the injector in regular code hides the dependencies
+func (s *Service) Find() string {
+	instance, _ := s.injector.GetInstance(new(Repository)) // want "Service Locator! \\(\\*dingo.Injector\\).GetInstance must only be called while bootstrapping"
+	if repository, ok := instance.(Repository); ok {
+		return repository.Find()
+	}
+	annotated, _ := s.injector.GetAnnotatedInstance(new(Repository), "a") // want "Service Locator! \\(\\*dingo.Injector\\).GetAnnotatedInstance must only be called while bootstrapping"
+	_ = annotated.(*repository)
+	_ = s.injector.RequestInjection(s) // want "Service Locator! \\(\\*dingo.Injector\\).RequestInjection must only be called while bootstrapping"
+	return ""
+}
+
+type Module struct{}
+
+// Configure methods are bootstrapping code
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(Repository)).To(new(repository))
+	go func() {
+		_, _ = injector.GetInstance(new(Repository))
+	}()
+}
+
+// The function creating the injector is bootstrapping code
+func NewService() *Service {
+	injector, _ := dingo.NewInjector(new(Module))
+	instance, _ := injector.GetInstance(new(Service))
+	return instance.(*Service)
+}
@@ -0,0 +1,12 @@
+package app
+
+import (
+	"testing"
+
+	"flamingo.me/dingo"
+)
+
+func TestFind(t *testing.T) {
+	injector, _ := dingo.NewInjector(new(Module))
+	_, _ = injector.GetInstance(new(Service))
+}
@@ -0,0 +1,13 @@
+package bootstrap
+
+import (
+	"flamingo.me/dingo"
+
+	"service_locator/app"
+)
+
+// Allowed by the configured paths
+func Start(injector *dingo.Injector) *app.Service {
+	instance, _ := injector.GetInstance(new(app.Service))
+	return instance.(*app.Service)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"flamingo.me/dingo"
+
+	"service_locator/app"
+)
+
+type Printer struct{}
+
+func (p *Printer) Print() {}
+
+type Runner interface {
+	Run()
+}
+
+func main() {
+	injector, _ := dingo.NewInjector(new(app.Module))
+
+	service, _ := injector.GetInstance(new(app.Service))
+	_ = service.(*app.Service)
+	_ = service.(app.Service)
+	_ = service.(**app.Service) // want "Impossible Type Assertion! The instance requested as \"app.Service\" can never be of type \"\\*\\*app.Service\""
+	_ = service.(Runner)        // want "Impossible Type Assertion! The instance requested as \"app.Service\" can never be of type \"main.Runner\""
+	_ = service.(fmt.Stringer)  // want "Impossible Type Assertion! The instance requested as \"app.Service\" can never be of type \"fmt.Stringer\""
+
+	var repository, _ = injector.GetAnnotatedInstance(new(app.Repository), "a")
+	switch repository.(type) {
+	case *Printer: // want "Impossible Type Assertion! The instance requested as \"app.Repository\" can never be of type \"\\*main.Printer\""
+	case app.Repository, fmt.Stringer:
+	case nil:
+	}
+
+	// Requests by reflection and reassigned variables are unknown
+	var of interface{} = new(app.Service)
+	unknown, _ := injector.GetInstance(of)
+	_ = unknown.(*Printer)
+	reassigned, _ := injector.GetInstance(new(app.Service))
+	reassigned = new(Printer)
+	_ = reassigned.(*Printer)
+}
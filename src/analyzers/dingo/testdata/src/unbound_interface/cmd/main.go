@@ -0,0 +1,13 @@
+package main
+
+import (
+	"unbound_interface/domain"
+	_ "unbound_interface/module" // want `Unbound Interface! "domain.Tagged" is injected as field "Tagged" of "service.Service" \(service.go:9\) but not bound in any module` `"domain.Unbound" is injected as parameter "unbound" of \(\*service.Service\).Inject \(service.go:15\)` `"domain.ProviderDependency" is injected as parameter "dependency" of the Provider bound in \(\*module.Module\).Configure \(module.go:15\)`
+)
+
+type app struct{}
+
+func (a *app) Inject(unbound domain.Unbound) { // want `"domain.Unbound" is injected as parameter "unbound" of \(\*main.app\).Inject \(main.go:10\)`
+}
+
+func main() {}
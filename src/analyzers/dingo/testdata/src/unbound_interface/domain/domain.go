@@ -0,0 +1,8 @@
+package domain
+
+type Bound interface{}
+type Unbound interface{}
+type Allowed interface{}
+type Multi interface{}
+type Tagged interface{}
+type ProviderDependency interface{}
@@ -0,0 +1,4 @@
+package external
+
+// Bound by a module which is not part of the analysed packages
+type External interface{}
@@ -0,0 +1,18 @@
+package module
+
+import (
+	"unbound_interface/domain"
+	"unbound_interface/service"
+
+	"flamingo.me/dingo"
+)
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(domain.Bound)).ToInstance(nil)
+	injector.BindMulti(new(domain.Multi)).ToInstance(nil)
+	injector.Bind(new(service.Service)).ToProvider(func(dependency domain.ProviderDependency, bound domain.Bound) *service.Service {
+		return new(service.Service)
+	})
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"unbound_interface/domain"
+	"unbound_interface/external"
+)
+
+type Service struct {
+	Tagged      domain.Tagged  `inject:""`
+	Annotated   domain.Unbound `inject:"annotated"`
+	Optional    domain.Unbound `inject:",optional"`
+	NotInjected domain.Unbound
+}
+
+func (s *Service) Inject(bound domain.Bound, unbound domain.Unbound, allowed domain.Allowed, multi domain.Multi, e external.External, name string, err error) {
+}
@@ -0,0 +1,121 @@
+package unused_injection
+
+type Logger interface {
+	Log(message string)
+}
+
+type Repository interface {
+	Find(id string) string
+}
+
+type Service struct {
+	logger     Logger
+	repository Repository
+	unused     Repository
+	name       string
+	cache      Repository `inject:"cache"` // want `Unused Injection! The field "cache" is injected by its inject tag but never read`
+	Fallback   Repository `inject:"fallback"`
+}
+
+func (s *Service) Inject(
+	logger Logger,
+	repository Repository, // want `Unused Injection! "repository" is injected into the field "repository" by \(\*unused_injection.Service\).Inject but the field is never read`
+	unused Repository,
+	cfg *struct {
+		Name  string `inject:"config:name"`
+		Debug bool   `inject:"config:debug"` // want `Unused Injection! The field "Debug" is injected by its inject tag but never read`
+	},
+) *Service {
+	s.logger = logger
+	s.repository = repository
+	s.unused = unused
+	s.unused = nil
+	if cfg != nil {
+		s.name = cfg.Name
+	}
+	return s
+}
+
+func (s *Service) Find(id string) string {
+	s.logger.Log(s.name)
+	return s.unused.Find(id)
+}
+
+type Exported struct {
+	Repository Repository
+}
+
+// Exported fields of exported types might be read by other packages
+func (e *Exported) Inject(repository Repository) {
+	e.Repository = repository
+}
+
+type private struct {
+	Repository Repository
+	logger     Logger
+}
+
+func (p *private) Inject(repository Repository, logger Logger) { // want `Unused Injection! "repository" is injected into the field "Repository"`
+	p.Repository = repository
+	p.logger = logger
+	p.logger.Log("injected")
+}
+
+type called struct {
+	logger Logger
+}
+
+// Removing the parameter would break the explicit call below, so no fix is suggested
+func (c *called) Inject(logger Logger) { // want `Unused Injection! "logger" is injected into the field "logger" by \(\*unused_injection.called\).Inject but the field is never read`
+	c.logger = logger
+}
+
+func newCalled(logger Logger) *called {
+	c := new(called)
+	c.Inject(logger)
+	return c
+}
+
+type pair struct {
+	logger     Logger
+	repository Repository
+	fallback   Repository
+}
+
+// Both unused parameters are removed by the same fix
+func (p *pair) Inject(logger Logger, repository Repository, fallback Repository) { // want `Unused Injection! "repository"` `Unused Injection! "fallback"`
+	p.logger = logger
+	p.repository = repository
+	p.fallback = fallback
+}
+
+func (p *pair) Log() {
+	p.logger.Log("pair")
+}
+
+type Responder struct {
+	Status int
+}
+
+func (r *Responder) Render() string {
+	return "rendered"
+}
+
+type Settings struct {
+	Name string
+}
+
+// Embedded fields are read by their promoted methods and fields
+type controller struct {
+	*Responder
+	*Settings
+}
+
+func (c *controller) Inject(responder *Responder, settings *Settings) {
+	c.Responder = responder
+	c.Settings = settings
+}
+
+func (c *controller) Handle() string {
+	return c.Render() + c.Name
+}
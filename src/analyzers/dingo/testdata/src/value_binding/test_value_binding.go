@@ -0,0 +1,46 @@
+package value_binding
+
+import (
+	"flamingo.me/dingo"
+)
+
+type I interface {
+	funA()
+}
+
+// pointer receiver, only *A implements I
+type A struct{}
+
+func (a *A) funA() {}
+
+// value receiver, B and *B implement I
+type B struct{}
+
+func (b B) funA() {}
+
+type Module struct{}
+
+func (*Module) Configure(injector *dingo.Injector) {
+	injector.Bind(new(I)).ToInstance(&A{})
+	injector.Bind(new(I)).ToInstance(A{})                      // want `Value Binding! "value_binding.A" is bound as value but only "\*value_binding.A" implements Interface "\*value_binding.I", bind a pointer instead`
+	injector.Bind(new(I)).AnnotatedWith("a").ToInstance((A{})) // want `Value Binding! "value_binding.A" is bound as value`
+	injector.BindMulti(new(I)).ToInstance(A{})                 // want `Value Binding! "value_binding.A" is bound as value`
+
+	// a value is kept if its own methods implement the interface
+	injector.Bind(new(I)).AnnotatedWith("b").ToInstance(B{})
+
+	// To() creates a new instance behind a pointer, a value only names the type
+	injector.Bind(new(I)).AnnotatedWith("c").To(A{})
+
+	// a variable isn't replaced by a pointer to it, that would share the variable
+	var a A
+	injector.Bind(new(I)).AnnotatedWith("d").ToInstance(a) // want `Value Binding! "value_binding.A" is bound as value`
+
+	// structs are copied, which is fine for the bound struct itself
+	injector.Bind(new(A)).ToInstance(A{})
+
+	// untyped nil has no type for Dingo to check
+	injector.Bind(new(I)).AnnotatedWith("e").ToInstance(nil) // want `Nil Binding! ToInstance\(nil\) has no type, Dingo panics when binding it to "\*value_binding.I"`
+	injector.Bind(new(A)).AnnotatedWith("f").ToInstance(nil) // want `Nil Binding! ToInstance\(nil\) has no type`
+	injector.Bind(new(I)).AnnotatedWith("g").ToInstance((*A)(nil))
+}
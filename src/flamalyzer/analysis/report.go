@@ -7,6 +7,7 @@ import (
 	"go/ast"
 	"go/printer"
 	"go/token"
+	"go/types"
 
 	"golang.org/x/tools/go/analysis"
 )
@@ -20,13 +21,20 @@ func prettyPrint(fset *token.FileSet, x interface{}) string {
 	return buf.String()
 }
 
+// Snippet returns the pretty-printed code of a node, e.g. to be shown next to the message of a Diagnostic.
+// A Diagnostic has no snippet field, as analysis.Diagnostic has no place to hand it on to the driver:
+// formatters show the source of the reported range, and this helper if they need the code as text.
+func Snippet(fset *token.FileSet, node ast.Node) string {
+	return prettyPrint(fset, node)
+}
+
 // Report an Error with nice printing. The Node which is the Point-of-Failure must be passed.
 //
 // Example:
 // analysis.Report(pass,"your error message", pointOfFailure)
 func Report(pass *analysis.Pass, message string, corruptNode ast.Node, args ...interface{}) {
 	args = append(args, prettyPrint(pass.Fset, corruptNode))
-	pass.Reportf(corruptNode.Pos(), message+"\n%s", args...)
+	pass.Report(analysis.Diagnostic{Pos: corruptNode.Pos(), End: corruptNode.End(), Message: fmt.Sprintf(message+"\n%s", args...)})
 }
 
 // ReportWithSuggestedFixes reports an Error with nice printing and suggested fixes. The Node which is the Point-of-Failure must be passed.
@@ -47,5 +55,50 @@ func Report(pass *analysis.Pass, message string, corruptNode ast.Node, args ...i
 // })
 func ReportWithSuggestedFixes(pass *analysis.Pass, format string, corruptNode ast.Node, suggestedFixes []analysis.SuggestedFix) {
 	msg := fmt.Sprintf(format, prettyPrint(pass.Fset, corruptNode))
-	pass.Report(analysis.Diagnostic{Pos: corruptNode.Pos(), Message: msg, SuggestedFixes: suggestedFixes})
+	pass.Report(analysis.Diagnostic{Pos: corruptNode.Pos(), End: corruptNode.End(), Message: msg, SuggestedFixes: suggestedFixes})
+}
+
+// Diagnostic is a finding which can involve more than one location.
+// In contrast to Report the code is not appended to the Message, formatters and editors
+// show the code of the reported range and of the related locations themselves.
+type Diagnostic struct {
+	// Node is the Point-of-Failure, its whole range gets reported
+	Node ast.Node
+	// Message describes the problem without embedding code
+	Message string
+	// Category optionally classifies the finding, e.g. the violated rule
+	Category string
+	// Related are further locations involved, e.g. the interface a binding must implement
+	Related        []analysis.RelatedInformation
+	SuggestedFixes []analysis.SuggestedFix
+}
+
+// ReportDiagnostic reports a Diagnostic with all its locations.
+//
+// Example:
+//
+//	analysis.ReportDiagnostic(pass, analysis.Diagnostic{
+//		Node:    pointOfFailure,
+//		Message: "your error message",
+//		Related: []analysis.RelatedInformation{analysis.RelatedObject(declaration, "declared here")},
+//	})
+func ReportDiagnostic(pass *analysis.Pass, diagnostic Diagnostic) {
+	pass.Report(analysis.Diagnostic{
+		Pos:            diagnostic.Node.Pos(),
+		End:            diagnostic.Node.End(),
+		Category:       diagnostic.Category,
+		Message:        diagnostic.Message,
+		Related:        diagnostic.Related,
+		SuggestedFixes: diagnostic.SuggestedFixes,
+	})
+}
+
+// RelatedNode creates a further location of a Diagnostic from a node
+func RelatedNode(node ast.Node, message string) analysis.RelatedInformation {
+	return analysis.RelatedInformation{Pos: node.Pos(), End: node.End(), Message: message}
+}
+
+// RelatedObject creates a further location of a Diagnostic pointing to the declaration of an object, e.g. a type
+func RelatedObject(obj types.Object, message string) analysis.RelatedInformation {
+	return analysis.RelatedInformation{Pos: obj.Pos(), End: obj.Pos() + token.Pos(len(obj.Name())), Message: message}
 }
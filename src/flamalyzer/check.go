@@ -0,0 +1,105 @@
+package flamalyzer
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"flamingo.me/flamalyzer/src/flamalyzer/driver"
+	"flamingo.me/flamalyzer/src/flamalyzer/format"
+	"flamingo.me/flamalyzer/src/flamalyzer/timing"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Runs the checks on the packages given at the command line with the checker of x/tools instead of the multichecker,
+// so the results of all checks are available once they finished, e.g. to write the timing table.
+// Returns the exit code, which is the same the multichecker would return.
+func (c *Controller) runChecker(checks []*analysis.Analyzer) int {
+	// The profiling flags of the multichecker are provided as well
+	cpuProfile := flag.String("cpuprofile", "", "write CPU profile to this file")
+	memProfile := flag.String("memprofile", "", "write memory profile to this file")
+	traceFile := flag.String("trace", "", "write trace log to this file")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "usage: %s [FLAGS] [PACKAGES]\n", os.Args[0])
+		flag.PrintDefaults()
+		return 1
+	}
+
+	if *cpuProfile != "" {
+		file, err := os.Create(*cpuProfile)
+		if err == nil {
+			err = pprof.StartCPUProfile(file)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer pprof.StopCPUProfile()
+	}
+	if *traceFile != "" {
+		file, err := os.Create(*traceFile)
+		if err == nil {
+			err = trace.Start(file)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer trace.Stop()
+	}
+	if *memProfile != "" {
+		defer func() {
+			file, err := os.Create(*memProfile)
+			if err == nil {
+				runtime.GC()
+				err = pprof.WriteHeapProfile(file)
+				file.Close()
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
+	}
+
+	exitCode := 0
+	// Test files are checked as well, like the multichecker does
+	pkgs, err := driver.Load(&packages.Config{Tests: true}, flag.Args()...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		exitCode = 1
+	}
+	graph, err := checker.Analyze(checks, pkgs, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if timingFile := c.config.GetTimingFile(); timingFile != "" {
+		recorder := timing.NewRecorder()
+		recorder.Record(graph)
+		if err := recorder.WriteFile(timingFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exitCode = 1
+		}
+	}
+	if c.config.GetFormat() == "human" {
+		format.NewHuman(os.Stdout).PrintGraph(graph)
+	} else if err := graph.PrintText(os.Stderr, -1); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if code := driver.ExitCode(graph); code > exitCode {
+		exitCode = code
+	}
+	return exitCode
+}
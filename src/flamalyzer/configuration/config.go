@@ -3,6 +3,7 @@
 package configuration
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -11,8 +12,6 @@ import (
 	"strings"
 
 	"flamingo.me/flamalyzer/src/flamalyzer/log"
-	"github.com/spf13/pflag"
-
 	"gopkg.in/yaml.v2"
 )
 
@@ -26,13 +25,22 @@ type AnalyzerConfig interface {
 type CoreConfig interface {
 	AnalyzerConfig
 	LoadConfigFromFiles()
+	LoadConfigFromFolder(folder string, suffix string)
+	RunsAsVetTool() bool
+	GetTimingFile() string
+	GetFormat() string
 }
 
+// The Config-Folder which is searched if no `--configFolder` is given
+const defaultConfigFolder = ".flamalyzer"
+
 // Config main struct
 type Config struct {
 	props            *configProps
 	configSuffixFlag *string
 	configFolderFlag *string
+	timingFlag       *string
+	formatFlag       *string
 }
 
 // This struct can be filled by config-files
@@ -55,23 +63,109 @@ func (c *Config) IsDebug() bool {
 	return *c.props.Debug
 }
 
+// GetTimingFile returns the file the timing table of the checks should be written to, empty if timing is disabled
+func (c *Config) GetTimingFile() string {
+	return *c.timingFlag
+}
+
+// GetFormat returns the output format of the findings, empty for the default format of vet
+func (c *Config) GetFormat() string {
+	return *c.formatFlag
+}
+
 // Set Config-Tags which can be used to use custom files with suffixes
 func (c *Config) prepareConfigFlags() {
-	fset := pflag.NewFlagSet("Flamalyzer", pflag.ContinueOnError)
+	fset := flag.NewFlagSet("Flamalyzer", flag.ContinueOnError)
+
+	c.props.Debug = fset.Bool("debugFlamalyzer", false, "Enables Flamalyzer debug messages")
+	c.configSuffixFlag = fset.String("configSuffix", "", "Suffix for Config-Files that should be loaded e.g `.mySuffix`")
+	c.configFolderFlag = fset.String("configFolder", "", "Path to the Config-Folder with Config-Files in it, by default `.flamalyzer` is searched in the working directory and above")
+	c.timingFlag = fset.String("timing", "", "Path to a file the time spent per check and package should be written to")
+	c.formatFlag = fset.String("format", "", "Output format of the findings, `human` shows the affected source lines")
+
+	// Register the flags at the command line as well, so the driver accepts them and `go vet` gets to know them by `-flags`
+	fset.VisitAll(func(f *flag.Flag) {
+		flag.Var(f.Value, f.Name, f.Usage)
+	})
+	// The driver parses the command line after the checks have been configured, so our own flags are picked beforehand
+	_ = fset.Parse(ownFlags(fset, os.Args[1:]))
+}
 
-	// todo maybe move all this to env vars to prevent the double declaration hassle
-	configSuffixMsg := "Suffix for Config-Files that should be loaded e.g `.mySuffix`"
-	configFolderMsg := "Path to the Config-Folder with Config-Files in it"
-	debugMsg := "Enables Flamalyzer debug messages"
-	flag.String("configSuffix", "", "use `--configSuffix` instead. "+configSuffixMsg)
-	flag.String("configFolder", "", "use `--configFolder` instead. "+configFolderMsg)
-	flag.Bool("debugFlamalyzer", true, "use `--debugFlamalyzer` instead. "+debugMsg)
-	c.props.Debug = fset.Bool("debugFlamalyzer", false, debugMsg)
-	c.configSuffixFlag = fset.String("configSuffix", "", configSuffixMsg)
-	c.configFolderFlag = fset.String("configFolder", "", configFolderMsg)
+// Returns the arguments which set one of the flags of the given FlagSet, in both styles `-name` and `--name`
+func ownFlags(fset *flag.FlagSet, args []string) []string {
+	var own []string
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			continue
+		}
+		name := strings.TrimLeft(args[i], "-")
+		hasValue := strings.Contains(name, "=")
+		if hasValue {
+			name = name[:strings.Index(name, "=")]
+		}
+		f := fset.Lookup(name)
+		if f == nil {
+			continue
+		}
+		own = append(own, args[i])
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && boolFlag.IsBoolFlag() {
+			continue
+		}
+		// The value is passed as separate argument e.g. `--configFolder path`
+		if !hasValue && i+1 < len(args) {
+			own = append(own, args[i+1])
+			i++
+		}
+	}
+	return own
+}
+
+// RunsAsVetTool determines whether Flamalyzer was started by `go vet -vettool` to analyse a single compilation unit
+func (c *Config) RunsAsVetTool() bool {
+	return unitConfigFile(os.Args[1:]) != ""
+}
 
-	fset.ParseErrorsWhitelist = pflag.ParseErrorsWhitelist{UnknownFlags: true}
-	_ = fset.Parse(os.Args[1:])
+// Returns the config-file `go vet` passes for a compilation unit as the only positional argument, if any
+func unitConfigFile(args []string) string {
+	if len(args) == 0 || !strings.HasSuffix(args[len(args)-1], ".cfg") {
+		return ""
+	}
+	return args[len(args)-1]
+}
+
+// Returns the directory the search for the Config-Folder starts in.
+// This is the package directory of the compilation unit if run by `go vet`, otherwise the working directory.
+func (c *Config) projectDir() string {
+	if file := unitConfigFile(os.Args[1:]); file != "" {
+		var unit struct {
+			Dir string
+		}
+		content, err := ioutil.ReadFile(file)
+		if err == nil {
+			err = json.Unmarshal(content, &unit)
+		}
+		if err == nil && unit.Dir != "" {
+			return unit.Dir
+		}
+		log.Println(fmt.Sprint("WARNING: Could not read the package directory from the vet config ", file, ": ", err), c.IsDebug())
+	}
+	dir, _ := os.Getwd()
+	return dir
+}
+
+// Searches the default Config-Folder in the given directory and all directories above
+func findConfigFolder(dir string) string {
+	for {
+		folder := filepath.Join(dir, defaultConfigFolder)
+		if info, err := os.Stat(folder); err == nil && info.IsDir() {
+			return folder
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
 }
 
 // LoadConfigFromFiles loads the data from the config-file and makes them available in the configProps
@@ -80,9 +174,27 @@ func (c *Config) LoadConfigFromFiles() {
 	// the defaultProps configured in the analyzers will be used
 	c.props = new(configProps)
 	c.prepareConfigFlags()
+	if *c.configFolderFlag == "" {
+		*c.configFolderFlag = findConfigFolder(c.projectDir())
+	}
+	c.readConfigFiles()
+}
+
+// LoadConfigFromFolder loads the data from the config-files in the given folder without evaluating any flags.
+// This is used to run the checks outside of the command line, e.g. in tests.
+func (c *Config) LoadConfigFromFolder(folder string, suffix string) {
+	c.props = &configProps{Debug: new(bool)}
+	c.configFolderFlag = &folder
+	c.configSuffixFlag = &suffix
+	c.timingFlag = new(string)
+	c.formatFlag = new(string)
+	c.readConfigFiles()
+}
 
+// Reads the config-files from the configured folder into the configProps
+func (c *Config) readConfigFiles() {
 	if *c.configFolderFlag == "" {
-		log.Println("WARNING: No configfolder defined, this means the default settings will be used!", c.IsDebug())
+		log.Println("WARNING: No configfolder defined and no `"+defaultConfigFolder+"` folder found, this means the default settings will be used!", c.IsDebug())
 		return
 	}
 
@@ -1,8 +1,12 @@
 package flamalyzer
 
 import (
+	"fmt"
+	"os"
+
 	"flamingo.me/flamalyzer/src/analyzers"
 	"flamingo.me/flamalyzer/src/flamalyzer/configuration"
+	"flamingo.me/flamalyzer/src/flamalyzer/log"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/multichecker"
 )
@@ -11,30 +15,53 @@ import (
 // It triggers the loading of the Config and holds all analyzers.
 // It also passes the individual checks of the analyzers to the driver (multichecker)
 type Controller struct {
-	config    configuration.CoreConfig
-	analyzers []analyzers.Analyzer
+	config                configuration.CoreConfig
+	analyzerProvider      analyzers.AnalyzerProvider
+	graphExporterProvider analyzers.GraphExporterProvider
 }
 
 // Inject dependencies
-func (c *Controller) Inject(config configuration.CoreConfig, analyzerProvider analyzers.AnalyzerProvider) {
+func (c *Controller) Inject(config configuration.CoreConfig, analyzerProvider analyzers.AnalyzerProvider, graphExporterProvider analyzers.GraphExporterProvider) {
 	c.config = config
-	c.analyzers = analyzerProvider()
+	c.analyzerProvider = analyzerProvider
+	c.graphExporterProvider = graphExporterProvider
 }
 
 // Get checks to run from the analyzers
-func (c *Controller) runAnalyzers() {
+func (c *Controller) checks() []*analysis.Analyzer {
 	var analysisChecks []*analysis.Analyzer
 
-	for _, a := range c.analyzers {
+	for _, a := range c.analyzerProvider() {
 		analysisChecks = append(analysisChecks, a.ChecksToExecute()...)
 	}
+	return analysisChecks
+}
+
+// Pass the checks of the analyzers to the driver
+func (c *Controller) runAnalyzers() {
+	analysisChecks := c.checks()
+	if c.config.RunsAsVetTool() {
+		// Every compilation unit is analysed by its own process, the driver passes the facts between them
+		log.Println("running as vet-tool, `--timing` and `--format` are ignored", c.config.IsDebug())
+	} else if c.config.GetTimingFile() != "" || c.config.GetFormat() == "human" {
+		// The timing table and the findings are written once all checks finished, so Flamalyzer runs the checker itself
+		os.Exit(c.runChecker(analysisChecks))
+	}
+	// Answers the probes of `go vet` (`-flags`, `-V=full`) and runs the unitchecker for the config-files it passes
 	multichecker.Main(
 		analysisChecks...,
 	)
 }
 
-// Run the analysis
+// Run the analysis, or export a graph if the subcommand `graph` is given
 func (c *Controller) Run() {
+	if len(os.Args) > 1 && os.Args[1] == graphCommand {
+		if err := c.runGraph(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	c.config.LoadConfigFromFiles()
 	c.runAnalyzers()
 }
@@ -0,0 +1,88 @@
+// Package driver runs analyzers on packages loaded from source with the checker of x/tools and returns their results,
+// this is used to export a model of the analysed packages instead of reporting findings
+// and to run the checks with output which differs from the one of vet.
+package driver
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Result is the result of the analyzer for one of the packages matching the patterns
+type Result struct {
+	Package *packages.Package
+	Value   interface{}
+}
+
+// Run loads the packages matching the patterns together with all their dependencies and runs the analyzer and the analyzers it requires on them.
+// Facts are passed on to the importing packages, findings are discarded.
+// The config is optional, e.g. to set the directory the patterns are relative to.
+func Run(config *packages.Config, analyzer *analysis.Analyzer, patterns ...string) ([]Result, error) {
+	pkgs, err := Load(config, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if err := firstError(pkgs); err != nil {
+		return nil, err
+	}
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(pkgs))
+	for _, action := range graph.Roots {
+		if action.Err != nil {
+			return nil, fmt.Errorf("running %s on %s failed: %v", analyzer.Name, action.Package.PkgPath, action.Err)
+		}
+		results = append(results, Result{Package: action.Package, Value: action.Result})
+	}
+	return results, nil
+}
+
+// Load loads the packages matching the patterns with the syntax of all their dependencies, as required by analyzers using facts.
+// Errors of single packages are kept in the packages, an error is returned only if nothing could be loaded.
+func Load(config *packages.Config, patterns ...string) ([]*packages.Package, error) {
+	cfg := new(packages.Config)
+	if config != nil {
+		*cfg = *config
+	}
+	cfg.Mode = packages.LoadAllSyntax | packages.NeedModule
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err == nil && len(pkgs) == 0 {
+		err = fmt.Errorf("%s matched no packages", strings.Join(patterns, " "))
+	}
+	return pkgs, err
+}
+
+// ExitCode returns the exit code of a run of the checks the same way vet does:
+// 1 if a check failed, 3 if the checks reported findings for the matching packages and 0 otherwise
+func ExitCode(graph *checker.Graph) int {
+	failed, findings := false, false
+	for _, action := range graph.Roots {
+		failed = failed || action.Err != nil
+		findings = findings || len(action.Diagnostics) > 0
+	}
+	switch {
+	case failed:
+		return 1
+	case findings:
+		return 3
+	}
+	return 0
+}
+
+// Returns the first error of the packages or of their dependencies
+func firstError(pkgs []*packages.Package) error {
+	var err error
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if len(pkg.Errors) > 0 && err == nil {
+			err = fmt.Errorf("loading %s failed: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+	})
+	return err
+}
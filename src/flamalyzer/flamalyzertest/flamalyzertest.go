@@ -0,0 +1,146 @@
+// Package flamalyzertest runs the checks of Flamalyzer modules on testdata the same way Flamalyzer does,
+// including the loading of the config-files, so analyzers and their configuration can be tested together.
+//
+// The testdata directory follows the layout of the "analysistest" package, the config-files are expected in
+// a `.flamalyzer` folder next to the `src` folder:
+//
+//	testdata/
+//		.flamalyzer/config.yaml
+//		src/mypackage/file.go
+//		src/mypackage/file.go.golden
+package flamalyzertest
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+
+	"flamingo.me/dingo"
+	"flamingo.me/flamalyzer/src/flamalyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// The flag is prefixed with the package name, so test packages can still define their own `-update` flag
+var update = flag.Bool("flamalyzertest.update", false, "update the golden files of flamalyzertest.Golden")
+
+// ConfigFolder is the name of the folder inside the testdata directory which holds the config-files
+const ConfigFolder = ".flamalyzer"
+
+// Run runs all checks of the given modules, configured by the config-files of the testdata directory,
+// on the packages matching the patterns. The findings are compared with the `// want` comments of the testdata.
+//
+// Example:
+// flamalyzertest.Run(t, analysistest.TestData(), []dingo.Module{new(myAnalyzer.Module)}, "mypackage")
+func Run(t analysistest.Testing, dir string, modules []dingo.Module, patterns ...string) []*analysistest.Result {
+	suite := Suite(t, dir, modules)
+	if suite == nil {
+		return nil
+	}
+	return analysistest.Run(t, dir, suite, patterns...)
+}
+
+// RunWithSuggestedFixes behaves like Run, but additionally applies the suggested fixes
+// and compares the result with the `.golden` files next to the checked files.
+func RunWithSuggestedFixes(t analysistest.Testing, dir string, modules []dingo.Module, patterns ...string) []*analysistest.Result {
+	suite := Suite(t, dir, modules)
+	if suite == nil {
+		return nil
+	}
+	return analysistest.RunWithSuggestedFixes(t, dir, suite, patterns...)
+}
+
+// Suite loads the checks of the given modules with the config-files of the testdata directory
+// and combines them into a single analysis.Analyzer, so the expectations of all checks can be verified in one run.
+func Suite(t analysistest.Testing, dir string, modules []dingo.Module) *analysis.Analyzer {
+	checks, err := flamalyzer.Checks(modules, filepath.Join(dir, ConfigFolder))
+	if err != nil {
+		t.Errorf("loading the checks failed: %v", err)
+		return nil
+	}
+	return combine(checks)
+}
+
+// Golden compares the output, e.g. of a formatter, with the content of the golden file.
+// If the tests are run with the `-flamalyzertest.update` flag the golden file is written instead.
+func Golden(t analysistest.Testing, goldenFile string, output []byte) {
+	if *update {
+		if err := ioutil.WriteFile(goldenFile, output, 0644); err != nil {
+			t.Errorf("writing golden file %s failed: %v", goldenFile, err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Errorf("reading golden file %s failed: %v", goldenFile, err)
+		return
+	}
+	if !bytes.Equal(want, output) {
+		t.Errorf("output does not match golden file %s\n--- want\n%s\n--- got\n%s", goldenFile, want, output)
+	}
+}
+
+// combine creates an analysis.Analyzer which runs all given checks on its own pass.
+// This way the diagnostics of every check are reported to the test instead of only those of a single check.
+func combine(checks []*analysis.Analyzer) *analysis.Analyzer {
+	isCheck := make(map[*analysis.Analyzer]bool)
+	for _, check := range checks {
+		isCheck[check] = true
+	}
+
+	// Checks requiring other checks must run after them, everything else is required by the combined analyzer
+	var ordered, requires []*analysis.Analyzer
+	var factTypes []analysis.Fact
+	visited := make(map[*analysis.Analyzer]bool)
+	knownFacts := make(map[reflect.Type]bool)
+	var visit func(a *analysis.Analyzer)
+	visit = func(a *analysis.Analyzer) {
+		if visited[a] {
+			return
+		}
+		visited[a] = true
+		if !isCheck[a] {
+			requires = append(requires, a)
+			return
+		}
+		for _, required := range a.Requires {
+			visit(required)
+		}
+		for _, fact := range a.FactTypes {
+			if !knownFacts[reflect.TypeOf(fact)] {
+				knownFacts[reflect.TypeOf(fact)] = true
+				factTypes = append(factTypes, fact)
+			}
+		}
+		ordered = append(ordered, a)
+	}
+	for _, check := range checks {
+		visit(check)
+	}
+
+	return &analysis.Analyzer{
+		Name:      "flamalyzer",
+		Doc:       "runs all configured checks of Flamalyzer",
+		Requires:  requires,
+		FactTypes: factTypes,
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			resultOf := make(map[*analysis.Analyzer]interface{}, len(pass.ResultOf)+len(ordered))
+			for a, result := range pass.ResultOf {
+				resultOf[a] = result
+			}
+			for _, check := range ordered {
+				checkPass := *pass
+				checkPass.Analyzer = check
+				checkPass.ResultOf = resultOf
+				result, err := check.Run(&checkPass)
+				if err != nil {
+					return nil, err
+				}
+				resultOf[check] = result
+			}
+			return nil, nil
+		},
+	}
+}
@@ -0,0 +1,203 @@
+// Package format renders the findings of the checks in different output formats
+package format
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// Severity which is shown for every finding, the checks don't distinguish between severities
+const severity = "error"
+
+// ANSI escape codes used if the output is a terminal
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorRed   = "\033[1;31m"
+	colorBlue  = "\033[1;34m"
+	colorCyan  = "\033[1;36m"
+)
+
+// Human renders findings with the affected source lines, line numbers and a caret underlining the reported range
+//
+// Example:
+//
+//	error[checkCorrectInterfaceToInstanceBinding]: Incorrect Binding! "*example.B" must implement Interface "*example.I"
+//	  --> example/module.go:12:27
+//	   |
+//	12 |	injector.Bind(new(I)).To(new(B))
+//	   |	                         ^^^^^^
+//	   = note: "I" is declared here --> example/module.go:5:6
+//	   = help: check if the Binding of an Interface to an Implementation with the Bind() -Function is possible
+type Human struct {
+	mu    sync.Mutex
+	out   io.Writer
+	color bool
+	seen  map[string]bool
+	files map[string][]string
+}
+
+// NewHuman creates a Human formatter writing to the given output.
+// Colors are used only if the output is a terminal and `NO_COLOR` is not set.
+func NewHuman(out io.Writer) *Human {
+	file, isFile := out.(*os.File)
+	return &Human{
+		out:   out,
+		color: isFile && isTerminal(file) && os.Getenv("NO_COLOR") == "",
+		seen:  make(map[string]bool),
+		files: make(map[string][]string),
+	}
+}
+
+// PrintGraph renders the findings of the checks on the packages matching the patterns, i.e. the roots of the graph, ordered by their position.
+// Findings on imported packages, which are analysed only for the facts of the checks, are left out.
+// Checks which failed are written to stderr.
+func (h *Human) PrintGraph(graph *checker.Graph) {
+	type finding struct {
+		action     *checker.Action
+		diagnostic analysis.Diagnostic
+		position   token.Position
+	}
+	var findings []finding
+	for _, action := range graph.Roots {
+		if action.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", action.Analyzer.Name, action.Err)
+			continue
+		}
+		for _, diagnostic := range action.Diagnostics {
+			findings = append(findings, finding{action: action, diagnostic: diagnostic, position: action.Package.Fset.Position(diagnostic.Pos)})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].position, findings[j].position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	for _, f := range findings {
+		h.Print(f.action.Package.Fset, f.action.Analyzer, f.diagnostic)
+	}
+}
+
+// Print renders a single finding of the given check
+func (h *Human) Print(fset *token.FileSet, check *analysis.Analyzer, diagnostic analysis.Diagnostic) {
+	start := fset.Position(diagnostic.Pos)
+	end := start
+	if diagnostic.End.IsValid() {
+		end = fset.Position(diagnostic.End)
+	}
+	// Code appended to the message by flanalysis.Report is left out, the original source is shown instead
+	message := strings.SplitN(diagnostic.Message, "\n", 2)[0]
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Files belonging to multiple packages (e.g. foo and foo.test) are analysed twice
+	key := fmt.Sprintf("%s %s %s %s", start, end, check.Name, message)
+	if h.seen[key] {
+		return
+	}
+	h.seen[key] = true
+
+	lines := h.lines(start.Filename)
+	gutter := strings.Repeat(" ", len(fmt.Sprint(end.Line)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s\n", h.paint(colorRed, fmt.Sprintf("%s[%s]", severity, check.Name)), h.paint(colorBold, message))
+	fmt.Fprintf(&b, "%s%s %s\n", gutter, h.paint(colorBlue, "-->"), start)
+	if start.Line >= 1 && end.Line <= len(lines) {
+		fmt.Fprintf(&b, "%s %s\n", gutter, h.paint(colorBlue, "|"))
+		for line := start.Line; line <= end.Line; line++ {
+			text := lines[line-1]
+			// lines continuing the range are underlined from their indentation on
+			from := len(text) - len(strings.TrimLeft(text, " \t")) + 1
+			to := len(text) + 1
+			if line == start.Line {
+				from = start.Column
+			}
+			if line == end.Line {
+				to = end.Column
+			}
+			fmt.Fprintf(&b, "%*d %s %s\n", len(gutter), line, h.paint(colorBlue, "|"), text)
+			fmt.Fprintf(&b, "%s %s %s\n", gutter, h.paint(colorBlue, "|"), h.paint(colorRed, underline(text, from, to)))
+		}
+	}
+	for _, related := range diagnostic.Related {
+		fmt.Fprintf(&b, "%s %s %s --> %s\n", gutter, h.paint(colorCyan, "= note:"), related.Message, fset.Position(related.Pos))
+	}
+	for _, hint := range hints(check, diagnostic) {
+		fmt.Fprintf(&b, "%s %s %s\n", gutter, h.paint(colorCyan, "= help:"), hint)
+	}
+	fmt.Fprintln(&b)
+
+	_, _ = io.WriteString(h.out, b.String())
+}
+
+// Returns the lines of a source file, files are read only once
+func (h *Human) lines(filename string) []string {
+	if lines, ok := h.files[filename]; ok {
+		return lines
+	}
+	var lines []string
+	if content, err := ioutil.ReadFile(filename); err == nil {
+		lines = strings.Split(string(content), "\n")
+	}
+	h.files[filename] = lines
+	return lines
+}
+
+func (h *Human) paint(color string, text string) string {
+	if !h.color {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// The messages of the suggested fixes are the best hint, otherwise the documentation of the check is shown
+func hints(check *analysis.Analyzer, diagnostic analysis.Diagnostic) []string {
+	var hints []string
+	for _, fix := range diagnostic.SuggestedFixes {
+		hints = append(hints, fix.Message)
+	}
+	if len(hints) == 0 && check.Doc != "" {
+		hints = append(hints, strings.SplitN(check.Doc, "\n", 2)[0])
+	}
+	return hints
+}
+
+// Returns the carets for the columns from (inclusive) to (exclusive) of the line.
+// Tabs before the range are kept so the carets line up with the source.
+func underline(text string, from int, to int) string {
+	if to <= from {
+		to = from + 1
+	}
+	var b strings.Builder
+	for i := 1; i < from; i++ {
+		if i <= len(text) && text[i-1] == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	b.WriteString(strings.Repeat("^", to-from))
+	return b.String()
+}
+
+// Checks if the file is a terminal
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
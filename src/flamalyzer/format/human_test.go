@@ -0,0 +1,94 @@
+package format_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"flamingo.me/flamalyzer/src/flamalyzer/flamalyzertest"
+	"flamingo.me/flamalyzer/src/flamalyzer/format"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestHuman(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "testdata/example.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the arguments of the inner calls are the reported targets, the interface is the related location
+	var targets []ast.Expr
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if _, ok := call.Fun.(*ast.CallExpr); ok {
+				targets = append(targets, call.Args[0])
+			}
+		}
+		return true
+	})
+	declaration := file.Scope.Lookup("I").Decl.(*ast.TypeSpec).Name
+
+	check := &analysis.Analyzer{Name: "checkExample", Doc: "check the example\nmore details"}
+	var out bytes.Buffer
+	human := format.NewHuman(&out)
+	human.Print(fset, check, analysis.Diagnostic{
+		Pos:     targets[0].Pos(),
+		End:     targets[0].End(),
+		Message: "B must implement I\nnew(B)",
+		Related: []analysis.RelatedInformation{{Pos: declaration.Pos(), End: declaration.End(), Message: "I is declared here"}},
+	})
+	human.Print(fset, check, analysis.Diagnostic{
+		Pos:            targets[1].Pos(),
+		End:            targets[1].End(),
+		Message:        "the struct must implement I",
+		SuggestedFixes: []analysis.SuggestedFix{{Message: "implement Do()"}},
+	})
+	// reported twice, e.g. by a test package, but printed once
+	human.Print(fset, check, analysis.Diagnostic{Pos: targets[0].Pos(), End: targets[0].End(), Message: "B must implement I"})
+
+	flamalyzertest.Golden(t, "testdata/human.golden", out.Bytes())
+}
+
+func TestHumanGraph(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "testdata/example.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bindings := file.Decls[len(file.Decls)-1].(*ast.FuncDecl).Body.List
+	pkg := &packages.Package{PkgPath: "example", Fset: fset}
+	imported := &packages.Package{PkgPath: "imported", Fset: fset}
+	check := &analysis.Analyzer{Name: "checkExample", Doc: "check the example"}
+
+	// the findings of the matching package are printed in the order of the source, those of the imported package are not
+	graph := &checker.Graph{Roots: []*checker.Action{{
+		Analyzer: check,
+		Package:  pkg,
+		IsRoot:   true,
+		Diagnostics: []analysis.Diagnostic{
+			{Pos: bindings[1].Pos(), Message: "second binding"},
+			{Pos: bindings[0].Pos(), Message: "first binding"},
+		},
+		Deps: []*checker.Action{{
+			Analyzer:    check,
+			Package:     imported,
+			Diagnostics: []analysis.Diagnostic{{Pos: bindings[0].Pos(), Message: "imported binding"}},
+		}},
+	}}}
+	var out bytes.Buffer
+	format.NewHuman(&out).PrintGraph(graph)
+
+	got := out.String()
+	first, second := strings.Index(got, "first binding"), strings.Index(got, "second binding")
+	if first < 0 || second < first {
+		t.Errorf("expected the findings of the package in the order of the source, got\n%s", got)
+	}
+	if strings.Contains(got, "imported binding") {
+		t.Errorf("expected no findings of the imported package, got\n%s", got)
+	}
+}
@@ -0,0 +1,14 @@
+package example
+
+type I interface {
+	Do()
+}
+
+type B struct{}
+
+func configure(bind func(what interface{}) func(to interface{})) {
+	bind(new(I))(new(B))
+	bind(new(I))(&struct {
+		B
+	}{})
+}
@@ -0,0 +1,53 @@
+package flamalyzer
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// The subcommand exporting a graph instead of running the checks
+const graphCommand = "graph"
+
+// Runs the subcommand `graph <name> [--format=FORMAT] [--output=FILE] [PACKAGES]` with the graph exporter of the given name
+func (c *Controller) runGraph(args []string) error {
+	exporters := c.graphExporterProvider()
+	var names []string
+	for _, exporter := range exporters {
+		names = append(names, exporter.Name())
+	}
+	usage := fmt.Sprintf("usage: flamalyzer %s <%s> [--format=FORMAT] [--output=FILE] [PACKAGES]", graphCommand, strings.Join(names, "|"))
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("%s", usage)
+	}
+
+	fset := flag.NewFlagSet(graphCommand+" "+args[0], flag.ContinueOnError)
+	format := fset.String("format", "dot", "Output format of the graph, e.g. `dot`, `mermaid` or `json`")
+	output := fset.String("output", "", "Path to the file the graph is written to, by default it is written to stdout")
+	if err := fset.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%s", usage)
+	}
+	patterns := fset.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	for _, exporter := range exporters {
+		if exporter.Name() != args[0] {
+			continue
+		}
+		var out io.Writer = os.Stdout
+		if *output != "" {
+			file, err := os.Create(*output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+		return exporter.Export(out, *format, patterns)
+	}
+	return fmt.Errorf("unknown graph %q\n%s", args[0], usage)
+}
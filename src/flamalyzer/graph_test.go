@@ -0,0 +1,64 @@
+package flamalyzer
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"flamingo.me/flamalyzer/src/analyzers"
+)
+
+// exporter writes the arguments it was called with
+type exporter struct{}
+
+func (e *exporter) Name() string {
+	return "example"
+}
+
+func (e *exporter) Export(w io.Writer, format string, patterns []string) error {
+	_, err := fmt.Fprintf(w, "%s %s", format, strings.Join(patterns, " "))
+	return err
+}
+
+func TestRunGraph(t *testing.T) {
+	c := &Controller{graphExporterProvider: func() []analyzers.GraphExporter {
+		return []analyzers.GraphExporter{new(exporter)}
+	}}
+	output := filepath.Join(t.TempDir(), "graph.out")
+
+	tests := []struct {
+		args []string
+		want string
+		err  string
+	}{
+		{args: []string{"example", "--output=" + output}, want: "dot ./..."},
+		{args: []string{"example", "--format", "json", "--output", output, "./cmd/...", "./app"}, want: "json ./cmd/... ./app"},
+		{args: []string{}, err: "usage: flamalyzer graph <example>"},
+		{args: []string{"--format=json", "example"}, err: "usage: flamalyzer graph <example>"},
+		{args: []string{"example", "--unknown"}, err: "usage: flamalyzer graph <example>"},
+		{args: []string{"other"}, err: `unknown graph "other"`},
+	}
+	for _, test := range tests {
+		err := c.runGraph(test.args)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%v: got error %v, want %q", test.args, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.args, err)
+			continue
+		}
+		got, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.want {
+			t.Errorf("%v: got %q, want %q", test.args, got, test.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"flamingo.me/dingo"
 	"flamingo.me/flamalyzer/src/flamalyzer/configuration"
+	"golang.org/x/tools/go/analysis"
 )
 
 // module to set up the core functionality of Flamalyzer
@@ -19,18 +20,37 @@ func (m *module) Configure(injector *dingo.Injector) {
 
 // Run with the given modules.
 func Run(modules []dingo.Module) {
+	service, err := newController(modules)
+	if err != nil {
+		log.Fatal(err)
+	}
+	service.Run()
+}
+
+// Checks returns the checks of the given modules configured by the config-files in the given folder.
+// Flags are not evaluated, this is used to run the checks outside of the command line, e.g. in tests.
+func Checks(modules []dingo.Module, configFolder string) ([]*analysis.Analyzer, error) {
+	service, err := newController(modules)
+	if err != nil {
+		return nil, err
+	}
+	service.config.LoadConfigFromFolder(configFolder, "")
+	return service.checks(), nil
+}
+
+// Creates the Controller with the core module and the given modules
+func newController(modules []dingo.Module) (*Controller, error) {
 	modules = append([]dingo.Module{new(module)}, modules...)
 
 	injector, err := dingo.NewInjector(
 		modules...,
 	)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	service, err := injector.GetInstance(new(Controller))
-
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	service.(*Controller).Run()
+	return service.(*Controller), nil
 }
@@ -0,0 +1,89 @@
+// Package timing measures the time spent by the checks of Flamalyzer
+package timing
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// Recorder collects the time every analysis.Analyzer spends per package and writes it as a table to a file.
+// The times are collected in memory and written once after all checks finished.
+type Recorder struct {
+	durations map[string]map[string]time.Duration
+}
+
+// NewRecorder creates an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		durations: make(map[string]map[string]time.Duration),
+	}
+}
+
+// Record adds the time spent by every action of the checker, this includes the analyzers required by the checks
+// (e.g. inspect.Analyzer) and the runs on imported packages of checks using facts
+func (r *Recorder) Record(graph *checker.Graph) {
+	for action := range graph.All() {
+		r.Add(action.Analyzer.Name, action.Package.PkgPath, action.Duration)
+	}
+}
+
+// Add adds the duration of one run of the analyzer on the package
+func (r *Recorder) Add(analyzer string, pkg string, d time.Duration) {
+	if r.durations[analyzer] == nil {
+		r.durations[analyzer] = make(map[string]time.Duration)
+	}
+	r.durations[analyzer][pkg] += d
+}
+
+// WriteFile writes the table of the recorded times to the file
+func (r *Recorder) WriteFile(file string) error {
+	return ioutil.WriteFile(file, r.table(), 0644)
+}
+
+type row struct {
+	analyzer string
+	pkg      string
+	duration time.Duration
+}
+
+// table renders the totals per check followed by the time per check and package, slowest first
+func (r *Recorder) table() []byte {
+	var totals, rows []row
+	for analyzer, packages := range r.durations {
+		var total time.Duration
+		for pkg, d := range packages {
+			total += d
+			rows = append(rows, row{analyzer: analyzer, pkg: pkg, duration: d})
+		}
+		totals = append(totals, row{analyzer: analyzer, pkg: fmt.Sprintf("(%d packages)", len(packages)), duration: total})
+	}
+	sortRows(totals)
+	sortRows(rows)
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tPACKAGE\tDURATION")
+	for _, row := range append(totals, rows...) {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", row.analyzer, row.pkg, row.duration)
+	}
+	_ = w.Flush()
+	return buf.Bytes()
+}
+
+func sortRows(rows []row) {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].duration != rows[j].duration {
+			return rows[i].duration > rows[j].duration
+		}
+		if rows[i].analyzer != rows[j].analyzer {
+			return rows[i].analyzer < rows[j].analyzer
+		}
+		return rows[i].pkg < rows[j].pkg
+	})
+}
@@ -0,0 +1,53 @@
+package timing_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"flamingo.me/flamalyzer/src/flamalyzer/timing"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestRecorder(t *testing.T) {
+	inspect := &analysis.Analyzer{Name: "inspect"}
+	check := &analysis.Analyzer{Name: "checkExample"}
+	app := &packages.Package{PkgPath: "example/app"}
+	lib := &packages.Package{PkgPath: "example/lib"}
+
+	// the check on the app requires the inspection of the app and the check on the imported lib,
+	// the inspection of the app is shared with the second root
+	inspectApp := &checker.Action{Analyzer: inspect, Package: app, Duration: 3 * time.Millisecond}
+	checkLib := &checker.Action{Analyzer: check, Package: lib, Duration: 5 * time.Millisecond}
+	graph := &checker.Graph{Roots: []*checker.Action{
+		{Analyzer: check, Package: app, Duration: 10 * time.Millisecond, Deps: []*checker.Action{inspectApp, checkLib}},
+		inspectApp,
+	}}
+
+	recorder := timing.NewRecorder()
+	recorder.Record(graph)
+	recorder.Add("inspect", "example/lib", time.Millisecond)
+
+	file := filepath.Join(t.TempDir(), "timing.txt")
+	if err := recorder.WriteFile(file); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `CHECK         PACKAGE       DURATION
+checkExample  (2 packages)  15ms
+inspect       (2 packages)  4ms
+checkExample  example/app   10ms
+checkExample  example/lib   5ms
+inspect       example/app   3ms
+inspect       example/lib   1ms
+`
+	if string(got) != want {
+		t.Errorf("unexpected table\n--- want\n%s\n--- got\n%s", want, got)
+	}
+}